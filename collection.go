@@ -0,0 +1,203 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Contains checks if container contains elem.
+// If container is a string, elem must be a string and is checked using [strings.Contains].
+// If container is a slice or an array, this checks if any element is equal to elem.
+// If container is a map, this checks if any key is equal to elem.
+// Values are compared using the same options as [Is.Equal].
+func (is Is) Contains(container, elem interface{}, format string, args ...interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	c := reflect.ValueOf(container)
+	switch c.Kind() {
+	case reflect.String:
+		substr, ok := elem.(string)
+		if !ok {
+			is.fail(errUnsupportedType, fmt.Sprintf("Cannot check if a string contains %T", elem), format, args...)
+			return
+		}
+
+		if !strings.Contains(c.String(), substr) {
+			is.fail(errNotContains, fmt.Sprintf("%q does not contain %q", c.String(), substr), format, args...)
+		}
+	case reflect.Slice, reflect.Array:
+		if indexOf(c, elem, s.options) == -1 {
+			is.fail(errNotContains, fmt.Sprintf("%+v does not contain %+v", container, elem), format, args...)
+		}
+	case reflect.Map:
+		for _, key := range c.MapKeys() {
+			if cmp.Equal(key.Interface(), elem, s.options.CmpOpts()...) {
+				return
+			}
+		}
+		is.fail(errNotContains, fmt.Sprintf("%+v does not contain the key %+v", container, elem), format, args...)
+	default:
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot check if %T contains an element", container), format, args...)
+	}
+}
+
+// ElementsMatch checks if value and expected contain the same elements ignoring their order.
+// Both values must be slices or arrays. Each element must appear the same number of times in both values.
+// Elements are compared using the same options as [Is.Equal].
+func (is Is) ElementsMatch(value, expected interface{}, format string, args ...interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	v, e := reflect.ValueOf(value), reflect.ValueOf(expected)
+	if !isList(v) || !isList(e) {
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot match the elements of %T and %T", value, expected), format, args...)
+		return
+	}
+
+	extra, missing := matchElements(v, e, s.options)
+
+	if len(extra) != 0 || len(missing) != 0 {
+		var b strings.Builder
+		b.WriteString("Elements do not match:")
+		writeElements(&b, "Extra elements", extra)
+		writeElements(&b, "Missing elements", missing)
+		is.fail(errElementsNotMatch, b.String(), format, args...)
+	}
+}
+
+// Subset checks if every element of sub is also contained in super.
+// If both values are slices or arrays, each element of sub must be equal to an element in super.
+// If both values are maps, each key of sub must exist in super with an equal value.
+// Values are compared using the same options as [Is.Equal].
+func (is Is) Subset(super, sub interface{}, format string, args ...interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	sup, sb := reflect.ValueOf(super), reflect.ValueOf(sub)
+	var missing []interface{}
+
+	switch {
+	case isList(sup) && isList(sb):
+		for i := 0; i < sb.Len(); i++ {
+			if elem := sb.Index(i).Interface(); indexOf(sup, elem, s.options) == -1 {
+				missing = append(missing, elem)
+			}
+		}
+	case sup.Kind() == reflect.Map && sb.Kind() == reflect.Map:
+		for _, key := range sb.MapKeys() {
+			elem := sb.MapIndex(key)
+			if supElem := sup.MapIndex(key); !supElem.IsValid() || !cmp.Equal(supElem.Interface(), elem.Interface(), s.options.CmpOpts()...) {
+				missing = append(missing, fmt.Sprintf("%+v: %+v", key, elem))
+			}
+		}
+	default:
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot check if %T is a subset of %T", sub, super), format, args...)
+		return
+	}
+
+	if len(missing) != 0 {
+		var b strings.Builder
+		b.WriteString("Value is not a subset:")
+		writeElements(&b, "Missing elements", missing)
+		is.fail(errNotSubset, b.String(), format, args...)
+	}
+}
+
+// Len checks if the length of v is n.
+// v must be a string, slice, array, map or channel.
+func (is Is) Len(v interface{}, n int, format string, args ...interface{}) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		if l := value.Len(); l != n {
+			is.t().Helper()
+			is.fail(errLen, fmt.Sprintf("Length is %d not %d", l, n), format, args...)
+		}
+	default:
+		is.t().Helper()
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot get the length of %T", v), format, args...)
+	}
+}
+
+// matchElements pairs each element of value with an equal element of expected.
+// This returns the elements of value and expected that could not be paired.
+// Pairs are found using augmenting paths, so the largest possible number of elements is paired even if
+// the comparison options are not transitive.
+func matchElements(value, expected reflect.Value, options *options) (extra, missing []interface{}) {
+	opts := options.CmpOpts()
+	equal := make([][]bool, value.Len())
+	for i := range equal {
+		equal[i] = make([]bool, expected.Len())
+		for j := range equal[i] {
+			equal[i][j] = cmp.Equal(expected.Index(j).Interface(), value.Index(i).Interface(), opts...)
+		}
+	}
+
+	// pairs[j] is the index of the element of value paired with the element j of expected, or -1.
+	pairs := make([]int, expected.Len())
+	for j := range pairs {
+		pairs[j] = -1
+	}
+
+	// pair tries to pair the element i of value, moving elements that were already paired if needed.
+	var pair func(i int, visited []bool) bool
+	pair = func(i int, visited []bool) bool {
+		for j := range pairs {
+			if !equal[i][j] || visited[j] {
+				continue
+			}
+
+			visited[j] = true
+			if pairs[j] == -1 || pair(pairs[j], visited) {
+				pairs[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range equal {
+		if !pair(i, make([]bool, len(pairs))) {
+			extra = append(extra, value.Index(i).Interface())
+		}
+	}
+
+	for j, i := range pairs {
+		if i == -1 {
+			missing = append(missing, expected.Index(j).Interface())
+		}
+	}
+	return extra, missing
+}
+
+// indexOf returns the index of the first element of list that is equal to elem.
+// This returns -1 if no elements are equal to elem.
+func indexOf(list reflect.Value, elem interface{}, options *options) int {
+	for i := 0; i < list.Len(); i++ {
+		if cmp.Equal(list.Index(i).Interface(), elem, options.CmpOpts()...) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isList checks if v is a slice or an array.
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// writeElements writes the given elements to b, one element per line.
+func writeElements(b *strings.Builder, title string, elems []interface{}) {
+	if len(elems) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n%s:", title)
+	for _, elem := range elems {
+		fmt.Fprintf(b, "\n\t%+v", elem)
+	}
+}
@@ -0,0 +1,74 @@
+package is
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestContains(t *testing.T) {
+	mustPass(t, func(is Is) { is.Contains("hello world", "world", "this should pass") })
+	mustFail(t, errNotContains, func(is Is) { is.Contains("hello world", "moon", "this should fail") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.Contains("hello world", 1, "this should fail") })
+
+	mustPass(t, func(is Is) { is.Contains([]int{1, 2, 3}, 2, "this should pass") })
+	mustPass(t, func(is Is) { is.Contains([2]deepIgnore{{V: 1}}, deepIgnore{V: 2}, "this should pass") })
+	mustFail(t, errNotContains, func(is Is) { is.Contains([]int{1, 2, 3}, 4, "this should fail") })
+
+	mustPass(t, func(is Is) { is.Contains(map[string]int{"a": 1}, "a", "this should pass") })
+	mustFail(t, errNotContains, func(is Is) { is.Contains(map[string]int{"a": 1}, "b", "this should fail") })
+
+	mustFail(t, errUnsupportedType, func(is Is) { is.Contains(1, 1, "this should fail") })
+
+	result := internal.Run(func(t internal.T) {
+		newIs(t, newOptions(nil)).Contains([]float64{math.NaN()}, math.NaN(), "")
+	})
+	if result.Failed {
+		t.Fatal("Contains did not use the options used by Equal")
+	}
+}
+
+func TestElementsMatch(t *testing.T) {
+	mustPass(t, func(is Is) { is.ElementsMatch([]int{1, 2, 2, 3}, []int{2, 3, 2, 1}, "this should pass") })
+	mustPass(t, func(is Is) { is.ElementsMatch([]int{}, [0]int{}, "this should pass") })
+	mustFail(t, errElementsNotMatch, func(is Is) { is.ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2}, "this should fail") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.ElementsMatch(map[int]int{}, []int{}, "this should fail") })
+
+	// 1.0 can only be paired with 0.96 if 1.1 is paired with 1.05.
+	result := internal.Run(func(t internal.T) {
+		newIs(t, newOptions([]Option{EquateApprox(0, 0.06)})).ElementsMatch([]float64{1.0, 1.1}, []float64{1.05, 0.96}, "")
+	})
+	if result.Failed {
+		t.Fatalf("Elements were not paired when a pairing exists: %s", result.FailMessage)
+	}
+
+	result = internal.Run(func(t internal.T) {
+		newIs(t, &options{}).ElementsMatch([]string{"a", "b", "c"}, []string{"c", "d", "a"}, "")
+	})
+
+	message := strings.Join(result.FailMessage, "\n")
+	if !strings.Contains(message, "Extra elements:\n\tb") || !strings.Contains(message, "Missing elements:\n\td") {
+		t.Fatalf("Incorrect failure message: %s", message)
+	}
+}
+
+func TestSubset(t *testing.T) {
+	mustPass(t, func(is Is) { is.Subset([]int{1, 2, 3}, []int{3, 1}, "this should pass") })
+	mustFail(t, errNotSubset, func(is Is) { is.Subset([]int{1, 2, 3}, []int{4, 1}, "this should fail") })
+
+	mustPass(t, func(is Is) { is.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2}, "this should pass") })
+	mustFail(t, errNotSubset, func(is Is) { is.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3}, "this should fail") })
+	mustFail(t, errNotSubset, func(is Is) { is.Subset(map[string]int{"a": 1}, map[string]int{"c": 1}, "this should fail") })
+
+	mustFail(t, errUnsupportedType, func(is Is) { is.Subset([]int{1}, map[int]int{}, "this should fail") })
+}
+
+func TestLen(t *testing.T) {
+	mustPass(t, func(is Is) { is.Len("abc", 3, "this should pass") })
+	mustPass(t, func(is Is) { is.Len([]int{1, 2}, 2, "this should pass") })
+	mustPass(t, func(is Is) { is.Len(map[int]int{1: 1}, 1, "this should pass") })
+	mustFail(t, errLen, func(is Is) { is.Len([3]int{}, 2, "this should fail") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.Len(1, 1, "this should fail") })
+}
@@ -0,0 +1,94 @@
+package is
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Context returns a context that is cancelled when the test completes.
+// The deadline of the context is the earliest of the deadline set by the -timeout flag of go test
+// and the deadline set by the [Timeout] option or [Is.RunTimeout].
+// Subtests started using [Is.Run] and [Is.RunP] and tests in a suite get a context derived from
+// the context of the test that started them.
+func (is Is) Context() context.Context {
+	return is.state().test.context()
+}
+
+// testInfo is information about a test that is shared by all [Is] values of the test.
+type testInfo struct {
+	t      internal.T
+	parent *testInfo
+
+	// parallel is set if the test was started using [Is.RunP] or a parallel suite.
+	parallel bool
+
+	// deadline is the deadline set by the timeout of the test.
+	deadline time.Time
+
+	// timedOut is set when the test times out. testGoroutine is the goroutine running the test, which
+	// can still use the test after it times out. timeoutMu is held for reading while the test is used.
+	// See [runTimeout].
+	timeoutMu     sync.RWMutex
+	timedOut      bool
+	testGoroutine int
+
+	ctxOnce sync.Once
+	ctx     context.Context
+
+	// goroutines are the ids of the goroutines other than the goroutine of the test that run the test.
+	// These are used by [DetectLeaks] to find goroutines started by the test after they exit.
+	goroutinesMu sync.Mutex
+	goroutines   map[int]bool
+}
+
+// newTestInfo creates the information for the test t.
+// parent is the test that started t and may be nil. parallel is set if t is a parallel test.
+// timeout is the timeout of t or zero.
+func newTestInfo(t internal.T, parent *testInfo, parallel bool, timeout time.Duration) *testInfo {
+	info := &testInfo{t: t, parent: parent, parallel: parallel}
+	if timeout > 0 {
+		info.deadline = time.Now().Add(timeout)
+	}
+	return info
+}
+
+// isParallel checks if the test or any of its parents are parallel tests.
+func (i *testInfo) isParallel() bool {
+	for ; i != nil; i = i.parent {
+		if i.parallel {
+			return true
+		}
+	}
+	return false
+}
+
+// context returns the context of the test.
+// The context is created when this is called for the first time and is cancelled when the test completes.
+func (i *testInfo) context() context.Context {
+	i.ctxOnce.Do(func() {
+		ctx := context.Background()
+		if i.parent != nil {
+			ctx = i.parent.context()
+		}
+
+		deadline, ok := i.t.Deadline()
+		if !i.deadline.IsZero() && (!ok || i.deadline.Before(deadline)) {
+			deadline, ok = i.deadline, true
+		}
+
+		var cancel context.CancelFunc
+		if ok {
+			ctx, cancel = context.WithDeadline(ctx, deadline)
+		} else {
+			ctx, cancel = context.WithCancel(ctx)
+		}
+
+		i.ctx = ctx
+		i.t.Cleanup(cancel)
+	})
+
+	return i.ctx
+}
@@ -0,0 +1,51 @@
+package is
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestContext(t *testing.T) {
+	test := &internal.Test{}
+	is := newIs(test, &options{})
+
+	ctx := is.Context()
+	if ctx != is.Context() || ctx != is.Soft().Context() {
+		t.Fatal("Context returned different contexts for the same test")
+	}
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("Context has a deadline without a timeout")
+	}
+
+	var subCtx, timeoutCtx interface{ Err() error }
+	is.Run("sub", func(is Is) { subCtx = is.Context() })
+	is.RunTimeout("timeout", time.Hour, func(is Is) {
+		ctx := is.Context()
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Hour {
+			t.Fatal("Context does not have the deadline set by the timeout")
+		}
+		timeoutCtx = ctx
+	})
+
+	if ctx.Err() != nil || subCtx.Err() != nil || timeoutCtx.Err() != nil {
+		t.Fatal("Context was cancelled before the test completed")
+	}
+
+	// cancel the context of the parent test.
+	test.CleanupFuncs[0]()
+
+	if ctx.Err() == nil || subCtx.Err() == nil || timeoutCtx.Err() == nil {
+		t.Fatal("Context was not cancelled when the test completed")
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	New(t).RunP("sub", func(is Is) {
+		expected, ok := is.T().Deadline()
+		deadline, ctxOk := is.Context().Deadline()
+		is(ok == ctxOk && deadline.Equal(expected), "Context does not have the deadline of the test")
+	})
+}
@@ -0,0 +1,108 @@
+package is
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// sortDependencies orders the tests of s so that each test is run after the tests it depends on.
+// Tests are otherwise kept in their current order.
+// This calls fatal if dependencies refers to a test that does not exist or contains a cycle.
+func (s *testSuite) sortDependencies(fatal func(err error, f string, a ...interface{}), dependencies map[string][]string) {
+	if len(dependencies) == 0 {
+		return
+	}
+
+	tests := make(map[string]bool, len(s.tests))
+	for _, test := range s.tests {
+		tests[test.Name] = true
+	}
+
+	// sort the names so that errors are deterministic.
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s.dependencies = make(map[string][]string, len(dependencies))
+	s.hasDependencies = make(map[string]bool)
+	for _, name := range names {
+		if !tests[name] {
+			fatal(errDependency, "is.Suite: Dependencies contains unknown test %s.", name)
+		}
+
+		for _, dependency := range dependencies[name] {
+			if !tests[dependency] {
+				fatal(errDependency, "is.Suite: %s depends on unknown test %s.", name, dependency)
+			}
+
+			s.hasDependencies[name] = true
+			s.hasDependencies[dependency] = true
+		}
+
+		s.dependencies[name] = dependencies[name]
+	}
+
+	// repeatedly pick the first test that does not depend on a test that has not been picked yet.
+	sorted := make([]*test, 0, len(s.tests))
+	picked := make(map[string]bool, len(s.tests))
+	remaining := s.tests
+	for len(remaining) != 0 {
+		next := -1
+		for i, test := range remaining {
+			if s.dependenciesPicked(test.Name, picked) {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			var cycle []string
+			for _, test := range remaining {
+				cycle = append(cycle, test.Name)
+			}
+			fatal(errDependency, "is.Suite: Dependencies contain a cycle between %s.", strings.Join(cycle, ", "))
+		}
+
+		picked[remaining[next].Name] = true
+		sorted = append(sorted, remaining[next])
+		remaining = append(remaining[:next:next], remaining[next+1:]...)
+	}
+
+	s.tests = sorted
+}
+
+// dependenciesPicked checks if all dependencies of the given test are in picked.
+func (s *testSuite) dependenciesPicked(name string, picked map[string]bool) bool {
+	for _, dependency := range s.dependencies[name] {
+		if !picked[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+// testOutcome returns why t did not pass, or an empty string if t passed.
+func testOutcome(t internal.T) string {
+	var failed, skipped bool
+	switch t := t.(type) {
+	case *internal.Test:
+		failed, skipped = t.Failed, t.Skipped
+	case interface {
+		Failed() bool
+		Skipped() bool
+	}:
+		failed, skipped = t.Failed(), t.Skipped()
+	}
+
+	switch {
+	case failed:
+		return "failed"
+	case skipped:
+		return "was skipped"
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type testDependencies struct {
+	alphaFails   bool
+	createFails  bool
+	dependencies map[string][]string
+	events       []string
+}
+
+func (t *testDependencies) Dependencies() map[string][]string { return t.dependencies }
+
+func (t *testDependencies) TestAlpha(is Is) {
+	t.events = append(t.events, "alpha")
+	is(!t.alphaFails, "alpha failed")
+}
+func (t *testDependencies) TestCreate(is Is) {
+	t.events = append(t.events, "create")
+	is(!t.createFails, "create failed")
+}
+func (t *testDependencies) TestDelete(Is) { t.events = append(t.events, "delete") }
+func (t *testDependencies) TestUpdate(Is) { t.events = append(t.events, "update") }
+
+func newTestDependencies() *testDependencies {
+	return &testDependencies{dependencies: map[string][]string{"TestUpdate": {"TestCreate"}, "TestDelete": {"TestUpdate"}}}
+}
+
+func TestDependencies(t *testing.T) {
+	suite := newTestDependencies()
+	result := internal.Run(func(t internal.T) { makeSuite(t, suite, true, nil).Run(t) })
+	if result.Failed {
+		t.Fatalf("failed to run suite: %s", result.FailMessage)
+	}
+
+	if events := strings.Join(suite.events, ","); events != "alpha,create,update,delete" {
+		t.Fatalf("Tests were not run after their dependencies: %s", events)
+	}
+
+	for _, test := range result.RunTests {
+		if test.Parallel != (test.Name == "TestAlpha") {
+			t.Fatalf("Tests with dependencies must not be run in parallel: %s", test.Name)
+		}
+	}
+
+	// dependent tests must be skipped if a dependency fails.
+	suite = newTestDependencies()
+	suite.createFails = true
+	result = internal.Run(func(t internal.T) { makeSuite(t, suite, false, []Option{NonFatal()}).Run(t) })
+	if !result.Failed || !errors.Is(result.TestError, errCondition) {
+		t.Fatalf("Test did not fail")
+	}
+
+	if events := strings.Join(suite.events, ","); events != "alpha,create" {
+		t.Fatalf("Tests were run after their dependencies failed: %s", events)
+	}
+
+	if len(result.SkipMessage) != 2 || !strings.Contains(result.SkipMessage[0], "TestCreate failed") {
+		t.Fatalf("Skip message does not name the failed dependency: %s", result.SkipMessage)
+	}
+
+	// failures of tests that are not dependencies must not skip other tests.
+	suite = newTestDependencies()
+	suite.alphaFails = true
+	result = internal.Run(func(t internal.T) { makeSuite(t, suite, false, []Option{NonFatal()}).Run(t) })
+	if events := strings.Join(suite.events, ","); !result.Failed || events != "alpha,create,update,delete" {
+		t.Fatalf("Tests were skipped after an unrelated test failed: %s", events)
+	}
+}
+
+func TestDependenciesInvalid(t *testing.T) {
+	for _, dependencies := range []map[string][]string{
+		{"TestMissing": {"TestCreate"}},
+		{"TestUpdate": {"TestMissing"}},
+		{"TestUpdate": {"TestCreate"}, "TestCreate": {"TestDelete"}, "TestDelete": {"TestUpdate"}},
+		{"TestUpdate": {"TestUpdate"}},
+	} {
+		suite := &testDependencies{dependencies: dependencies}
+		result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil) })
+		if !result.Failed || !errors.Is(result.TestError, errDependency) {
+			t.Fatalf("allowed invalid dependencies %v", dependencies)
+		}
+	}
+
+	result := internal.Run(func(t internal.T) { makeSuite(t, &testDependenciesIncorrect{}, false, nil) })
+	if !result.Failed || !errors.Is(result.TestError, errMethodSignature) {
+		t.Fatalf("allowed suite with an invalid Dependencies method")
+	}
+}
+
+type testDependenciesIncorrect struct{ testTest }
+
+func (t *testDependenciesIncorrect) Dependencies() []string { return nil }
+
+type testDependenciesTimeout struct{}
+
+func (t *testDependenciesTimeout) Options() []Option { return []Option{Timeout(50 * time.Millisecond)} }
+func (t *testDependenciesTimeout) Dependencies() map[string][]string {
+	return map[string][]string{"TestUpdate": {"TestCreate"}}
+}
+
+func (t *testDependenciesTimeout) TestCreate(Is) { time.Sleep(time.Second) }
+func (t *testDependenciesTimeout) TestUpdate(Is) { fmt.Println("update ran") }
+
+func TestDependenciesTimeout(t *testing.T) {
+	// the suite is run in a separate process since a test that times out cannot pass.
+	if os.Getenv("IS_TEST_DEPENDENCIES_TIMEOUT") != "" {
+		Suite(t, &testDependenciesTimeout{})
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestDependenciesTimeout$", "-test.v")
+	cmd.Env = append(os.Environ(), "IS_TEST_DEPENDENCIES_TIMEOUT=1")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("Suite did not time out:\n%s", output)
+	}
+
+	if strings.Contains(string(output), "update ran") || !strings.Contains(string(output), "--- SKIP: TestDependenciesTimeout/TestUpdate") {
+		t.Fatalf("Test was run after its dependency timed out:\n%s", output)
+	}
+}
@@ -0,0 +1,149 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Setenv sets the environment variable key to value and restores its original value when the test completes.
+// Like [testing.T.Setenv], this cannot be used in parallel tests since it affects the whole process.
+func (is Is) Setenv(key, value string) {
+	s := is.state()
+	s.t.Helper()
+
+	if !is.checkNotParallel("is.Setenv") {
+		return
+	}
+
+	if t, ok := testingT(s.t); ok {
+		t.Setenv(key, value)
+		return
+	}
+
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to set environment variable: %s", err), "is.Setenv(%q)", key)
+		return
+	}
+
+	s.t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Chdir changes the working directory to dir and restores the original working directory when the
+// test completes. This cannot be used in parallel tests since it affects the whole process.
+func (is Is) Chdir(dir string) {
+	s := is.state()
+	s.t.Helper()
+
+	if !is.checkNotParallel("is.Chdir") {
+		return
+	}
+
+	old, err := os.Getwd()
+	if err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to get the working directory: %s", err), "is.Chdir(%q)", dir)
+		return
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to change the working directory: %s", err), "is.Chdir(%q)", dir)
+		return
+	}
+
+	s.t.Cleanup(func() { os.Chdir(old) })
+}
+
+// Patch sets the variable ptr points to to value and restores its original value when the test completes.
+// ptr must be a non-nil pointer and value must be assignable to the type ptr points to.
+// If value is nil, the variable is set to its zero value.
+// Patch is intended to be used to replace global variables so it cannot be used in parallel tests.
+//
+//	is.Patch(&timeNow, func() time.Time { return fixedTime })
+func (is Is) Patch(ptr, value interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	if !is.checkNotParallel("is.Patch") {
+		return
+	}
+
+	p := reflect.ValueOf(ptr)
+	if p.Kind() != reflect.Pointer || p.IsNil() {
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot patch %T, expected a non-nil pointer", ptr), "is.Patch")
+		return
+	}
+
+	target := p.Elem()
+	v := reflect.Zero(target.Type())
+	if value != nil {
+		v = reflect.ValueOf(value)
+		if !v.Type().AssignableTo(target.Type()) {
+			is.fail(errUnsupportedType, fmt.Sprintf("Cannot assign %s to %s", v.Type(), target.Type()), "is.Patch")
+			return
+		}
+	}
+
+	old := reflect.New(target.Type()).Elem()
+	old.Set(target)
+	target.Set(v)
+
+	s.t.Cleanup(func() { target.Set(old) })
+}
+
+// checkNotParallel fails the test if it is a parallel test.
+// This reports if the test is not a parallel test.
+func (is Is) checkNotParallel(fn string) bool {
+	s := is.state()
+	s.t.Helper()
+
+	parallel := s.test.isParallel()
+	if t, ok := testingT(s.t); ok && !parallel {
+		// This detects tests that called t.Parallel directly instead of using this package.
+		parallel = testingParallel(t)
+	}
+
+	if parallel {
+		is.fail(errParallel, fn+" cannot be used in parallel tests", fn)
+		return false
+	}
+	return true
+}
+
+// testingParallel checks if t or one of its parents called [testing.T.Parallel].
+// The state of t is only read, never modified.
+// This returns false if the state cannot be read on this version of Go.
+func testingParallel(t *testing.T) bool {
+	v := reflect.ValueOf(t).Elem()
+	if p := v.FieldByName("isParallel"); p.IsValid() && p.Kind() == reflect.Bool && p.Bool() {
+		return true
+	}
+
+	// parents are stored as the testing.common embedded in testing.T.
+	for c := v.FieldByName("common"); c.IsValid() && c.Kind() == reflect.Struct; {
+		if p := c.FieldByName("isParallel"); p.IsValid() && p.Kind() == reflect.Bool && p.Bool() {
+			return true
+		}
+		parent := c.FieldByName("parent")
+		if !parent.IsValid() || parent.Kind() != reflect.Ptr || parent.IsNil() {
+			break
+		}
+		c = parent.Elem()
+	}
+	return false
+}
+
+// testingT gets the [*testing.T] used by t if it has one.
+func testingT(t internal.T) (*testing.T, bool) {
+	tt, ok := unwrapT(t).(*testing.T)
+	return tt, ok
+}
@@ -0,0 +1,116 @@
+package is
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+var patchTarget = 1
+
+func runCleanup(test *internal.Test) {
+	for i := len(test.CleanupFuncs) - 1; i >= 0; i-- {
+		test.CleanupFuncs[i]()
+	}
+}
+
+func TestSetenv(t *testing.T) {
+	const key = "IS_TEST_SETENV"
+
+	test := &internal.Test{}
+	newIs(test, &options{}).Setenv(key, "value")
+	if os.Getenv(key) != "value" {
+		t.Fatal("Environment variable was not set")
+	}
+
+	runCleanup(test)
+	if _, ok := os.LookupEnv(key); ok {
+		t.Fatal("Environment variable was not restored")
+	}
+
+	New(t).Run("testing.T", func(is Is) {
+		is.Setenv(key, "value")
+		is(os.Getenv(key) == "value", "Environment variable was not set")
+	})
+
+	mustFail(t, errParallel, func(is Is) { is.RunP("parallel", func(is Is) { is.Setenv(key, "value") }) })
+}
+
+func TestChdir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := os.MkdirTemp("", "is-chdir-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	test := &internal.Test{}
+	newIs(test, &options{}).Chdir(dir)
+	if cwd, _ := os.Getwd(); cwd == wd {
+		t.Fatal("Working directory was not changed")
+	}
+
+	runCleanup(test)
+	if cwd, _ := os.Getwd(); cwd != wd {
+		t.Fatal("Working directory was not restored")
+	}
+
+	mustFail(t, errFileSystem, func(is Is) { is.Chdir(dir + "/missing") })
+	mustFail(t, errParallel, func(is Is) { is.RunP("parallel", func(is Is) { is.Chdir(dir) }) })
+}
+
+func TestPatch(t *testing.T) {
+	test := &internal.Test{}
+	newIs(test, &options{}).Patch(&patchTarget, 2)
+	if patchTarget != 2 {
+		t.Fatal("Variable was not patched")
+	}
+
+	runCleanup(test)
+	if patchTarget != 1 {
+		t.Fatal("Variable was not restored")
+	}
+
+	mustPass(t, func(is Is) {
+		var err error = os.ErrClosed
+		is.Patch(&err, nil)
+		is(err == nil, "Variable was not set to the zero value")
+	})
+
+	mustFail(t, errUnsupportedType, func(is Is) { is.Patch(&patchTarget, "2") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.Patch(patchTarget, 2) })
+	mustFail(t, errUnsupportedType, func(is Is) { is.Patch((*int)(nil), 2) })
+	mustFail(t, errParallel, func(is Is) { is.RunP("parallel", func(is Is) { is.Patch(&patchTarget, 2) }) })
+}
+
+func TestEnvParallel(t *testing.T) {
+	t.Parallel()
+
+	for name, fn := range map[string]func(is Is){
+		"Setenv": func(is Is) { is.Setenv("IS_TEST_SETENV", "value") },
+		"Chdir":  func(is Is) { is.Chdir(t.TempDir()) },
+		"Patch":  func(is Is) { is.Patch(&patchTarget, 2) },
+	} {
+		// failures are recorded so that this test does not fail.
+		rt := &recordT{T: t}
+		rt.run(func() { fn(newIs(rt, &options{})) })
+		if !rt.failed || !strings.Contains(rt.String(), "cannot be used in parallel tests") {
+			t.Fatalf("is.%s was allowed in a test that called t.Parallel", name)
+		}
+	}
+
+	t.Run("Subtest", func(t *testing.T) {
+		// subtests of a parallel test may run in parallel with other tests.
+		rt := &recordT{T: t}
+		rt.run(func() { newIs(rt, &options{}).Patch(&patchTarget, 2) })
+		if !rt.failed {
+			t.Fatal("is.Patch was allowed in a subtest of a test that called t.Parallel")
+		}
+	})
+}
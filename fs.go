@@ -0,0 +1,136 @@
+package is
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CmpFileMode sets if [Is.TreeEqual] compares the permission bits of files.
+// Default: false
+func CmpFileMode(eq bool) Option {
+	return func(o *options) { o.cmpFileMode = eq }
+}
+
+// TempTree creates a temporary directory containing the given files and returns its path.
+// files maps slash separated paths to the contents of the file. Parent directories are created as needed.
+// The directory is removed when the test completes.
+func (is Is) TempTree(files map[string]string) string {
+	t := is.t()
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "is-tree-")
+	if err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to create temporary directory: %s", err), "is.TempTree")
+		return ""
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for name, content := range files {
+		if !fs.ValidPath(name) {
+			is.fail(errFileSystem, fmt.Sprintf("Invalid path %q", name), "is.TempTree")
+			return dir
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			is.fail(errFileSystem, fmt.Sprintf("Failed to create directory: %s", err), "is.TempTree")
+			return dir
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			is.fail(errFileSystem, fmt.Sprintf("Failed to write file: %s", err), "is.TempTree")
+			return dir
+		}
+	}
+
+	return dir
+}
+
+// TreeEqual checks if the directory dir contains the same files as expected.
+// Only regular files are compared. Missing files, extra files and files with different contents are
+// reported separately for each path. If [CmpFileMode] is set, the permission bits of files are also compared.
+//
+// expected can be any [fs.FS], for example a [testing/fstest.MapFS] or the result of [os.DirFS].
+func (is Is) TreeEqual(dir string, expected fs.FS, format string, args ...interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	actualFiles, err := readTree(os.DirFS(dir))
+	if err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to read %s: %s", dir, err), format, args...)
+		return
+	}
+
+	expectedFiles, err := readTree(expected)
+	if err != nil {
+		is.fail(errFileSystem, fmt.Sprintf("Failed to read expected files: %s", err), format, args...)
+		return
+	}
+
+	paths := make([]string, 0, len(expectedFiles))
+	for path := range expectedFiles {
+		paths = append(paths, path)
+	}
+	for path := range actualFiles {
+		if _, ok := expectedFiles[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		a, okA := actualFiles[path]
+		e, okE := expectedFiles[path]
+		switch {
+		case !okA:
+			fmt.Fprintf(&b, "\n%s: missing", path)
+		case !okE:
+			fmt.Fprintf(&b, "\n%s: unexpected file", path)
+		default:
+			if a.content != e.content {
+				fmt.Fprintf(&b, "\n%s: content is not equal:\n%s", path, cmpValue(a.content, e.content, s.options))
+			}
+			if s.options.cmpFileMode && a.mode != e.mode {
+				fmt.Fprintf(&b, "\n%s: mode is %s not %s", path, a.mode, e.mode)
+			}
+		}
+	}
+
+	if b.Len() != 0 {
+		is.fail(errTreeNotEqual, fmt.Sprintf("Directory %s does not match the expected files:%s", dir, b.String()), format, args...)
+	}
+}
+
+type treeFile struct {
+	content string
+	mode    fs.FileMode
+}
+
+// readTree reads all regular files in fsys.
+func readTree(fsys fs.FS) (map[string]treeFile, error) {
+	files := map[string]treeFile{}
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		files[path] = treeFile{content: string(content), mode: info.Mode().Perm()}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,77 @@
+package is
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// tempDir makes t create temporary directories inside a directory that is removed when t completes.
+// This removes the trees created by [Is.TempTree] in tests that do not run the cleanups of [internal.Test].
+func tempDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, key := range []string{"TMPDIR", "TMP", "TEMP"} {
+		t.Setenv(key, dir)
+	}
+}
+
+func TestTempTree(t *testing.T) {
+	tempDir(t)
+
+	test := &internal.Test{}
+	dir := newIs(test, &options{}).TempTree(map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+
+	if data, err := os.ReadFile(filepath.Join(dir, "sub", "b.txt")); err != nil || string(data) != "b" {
+		t.Fatalf("File was not written correctly: %q %v", data, err)
+	}
+
+	for _, cleanup := range test.CleanupFuncs {
+		cleanup()
+	}
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatal("Directory was not removed")
+	}
+
+	mustFail(t, errFileSystem, func(is Is) { is.TempTree(map[string]string{"../a": ""}) })
+}
+
+func TestTreeEqual(t *testing.T) {
+	tempDir(t)
+
+	mustPass(t, func(is Is) {
+		dir := is.TempTree(map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+		is.TreeEqual(dir, fstest.MapFS{"a.txt": {Data: []byte("a")}, "sub/b.txt": {Data: []byte("b")}}, "this should pass")
+		is.TreeEqual(dir, os.DirFS(dir), "this should pass")
+	})
+
+	mustFail(t, errTreeNotEqual, func(is Is) {
+		dir := is.TempTree(map[string]string{"a.txt": "a"})
+		is.TreeEqual(dir, fstest.MapFS{"a.txt": {Data: []byte("b")}}, "this should fail")
+	})
+
+	mustFail(t, errFileSystem, func(is Is) {
+		is.TreeEqual(filepath.Join(t.TempDir(), "missing"), fstest.MapFS{}, "this should fail")
+	})
+
+	result := internal.Run(func(t internal.T) {
+		is := newIs(t, newOptions([]Option{CmpFileMode(true)}))
+		dir := is.TempTree(map[string]string{"a.txt": "a", "extra.txt": "", "same.txt": "same"})
+		is.TreeEqual(dir, fstest.MapFS{
+			"a.txt":       {Data: []byte("b"), Mode: 0o644},
+			"missing.txt": {Data: []byte("")},
+			"same.txt":    {Data: []byte("same"), Mode: 0},
+		}, "")
+	})
+
+	message := strings.Join(result.FailMessage, "\n")
+	for _, expected := range []string{"a.txt: content is not equal", "missing.txt: missing", "extra.txt: unexpected file", "same.txt: mode is -rw"} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("Failure message does not contain %q:\n%s", expected, message)
+		}
+	}
+}
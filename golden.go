@@ -0,0 +1,146 @@
+package is
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// update is set if golden files and snapshots should be updated instead of checked.
+var update = flag.Bool("is.update", false, "update golden files and inline snapshots instead of comparing against them")
+
+// Marshaler serializes values for [Is.Golden] and [Is.Snapshot].
+type Marshaler func(v interface{}) ([]byte, error)
+
+// GoldenMarshaler sets the function used to serialize values passed to [Is.Golden] and [Is.Snapshot].
+// Default: [MarshalText]
+func GoldenMarshaler(m Marshaler) Option {
+	return func(o *options) { o.marshaler = m }
+}
+
+// GoldenDir sets the directory golden files are stored in.
+// Default: testdata
+func GoldenDir(dir string) Option {
+	return func(o *options) { o.goldenDir = dir }
+}
+
+// Golden checks if value matches the contents of the golden file with the given name.
+// The value is serialized using the [Marshaler] set by [GoldenMarshaler] and compared with the file
+// testdata/<TestName>/<name>.golden. Subtests use their full name so each subtest has its own directory.
+//
+// If the test binary is run with the -is.update flag or the IS_UPDATE_GOLDEN=1 environment variable,
+// the golden file is written instead.
+func (is Is) Golden(name string, value interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	path := goldenPath(s.options.goldenDir, s.t.Name(), name)
+
+	data, err := s.options.marshal(value)
+	if err != nil {
+		is.fail(errSnapshot, fmt.Sprintf("Failed to serialize value: %s", err), "is.Golden(%q)", name)
+		return
+	}
+
+	if updateSnapshots() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			is.fail(errSnapshot, fmt.Sprintf("Failed to create directory: %s", err), "is.Golden(%q)", name)
+		} else if err := os.WriteFile(path, data, 0o644); err != nil {
+			is.fail(errSnapshot, fmt.Sprintf("Failed to write golden file: %s", err), "is.Golden(%q)", name)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		is.fail(errGoldenMissing, fmt.Sprintf("Golden file %s does not exist. Run the test with -is.update or IS_UPDATE_GOLDEN=1 to create it", path), "is.Golden(%q)", name)
+		return
+	} else if err != nil {
+		is.fail(errSnapshot, fmt.Sprintf("Failed to read golden file: %s", err), "is.Golden(%q)", name)
+		return
+	}
+
+	if !bytes.Equal(data, expected) {
+		is.fail(errGoldenNotEqual, fmt.Sprintf("Value does not match golden file %s:\n%s", path, cmpValue(string(data), string(expected), s.options)), "is.Golden(%q)", name)
+	}
+}
+
+// MarshalText serializes v as text.
+// Strings and byte slices are used as is. Values implementing [encoding.TextMarshaler], error or
+// [fmt.Stringer] are serialized using those interfaces. All other values are formatted using %+v.
+func MarshalText(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case error:
+		return []byte(v.Error()), nil
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	default:
+		return []byte(fmt.Sprintf("%+v", v)), nil
+	}
+}
+
+// MarshalJSON serializes v as indented JSON.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// marshal serializes v using the marshaler set by [GoldenMarshaler].
+func (o *options) marshal(v interface{}) ([]byte, error) {
+	if o.marshaler == nil {
+		return MarshalText(v)
+	}
+	return o.marshaler(v)
+}
+
+// updateSnapshots checks if golden files and inline snapshots should be updated.
+func updateSnapshots() bool {
+	return *update || os.Getenv("IS_UPDATE_GOLDEN") == "1"
+}
+
+// goldenPath returns the path of the golden file with the given name.
+// Each element of the test name and the golden file name is sanitized so that it is a valid file name.
+func goldenPath(dir, testName, name string) string {
+	if dir == "" {
+		dir = "testdata"
+	}
+
+	elems := []string{dir}
+	for _, elem := range strings.Split(testName+"/"+name+".golden", "/") {
+		if elem == "" {
+			continue
+		}
+		elems = append(elems, sanitizePathElem(elem))
+	}
+	return filepath.Join(elems...)
+}
+
+// sanitizePathElem replaces characters that may not be valid in a file name with '_'.
+func sanitizePathElem(elem string) string {
+	if elem == "." || elem == ".." {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, elem)
+}
@@ -0,0 +1,84 @@
+package is
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type goldenValue struct {
+	Name  string
+	Count int
+}
+
+func runGolden(t *testing.T, dir string, fn func(is Is)) *internal.Test {
+	t.Helper()
+	return internal.Run(func(it internal.T) {
+		it.(*internal.Test).TestName = "TestGolden"
+		fn(newIs(it, newOptions([]Option{GoldenDir(dir), GoldenMarshaler(MarshalJSON)})))
+	})
+}
+
+func TestGolden(t *testing.T) {
+	t.Setenv("IS_UPDATE_GOLDEN", "")
+	dir := t.TempDir()
+	value := goldenValue{Name: "a", Count: 1}
+
+	result := runGolden(t, dir, func(is Is) { is.Golden("value", value) })
+	if !result.Failed || !errors.Is(result.TestError, errGoldenMissing) {
+		t.Fatalf("Golden did not fail when the golden file is missing: %s", result.FailMessage)
+	}
+
+	t.Setenv("IS_UPDATE_GOLDEN", "1")
+	result = runGolden(t, dir, func(is Is) {
+		is.Golden("value", value)
+		is.Run("sub test", func(is Is) { is.Golden("value", "sub test value") })
+	})
+	if result.Failed {
+		t.Fatalf("Failed to update golden files: %s", result.FailMessage)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "TestGolden", "value.golden")); err != nil || string(data) != "{\n\t\"Name\": \"a\",\n\t\"Count\": 1\n}\n" {
+		t.Fatalf("Golden file was not written correctly: %q %v", data, err)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "TestGolden", "sub_test", "value.golden")); err != nil || string(data) != "\"sub test value\"\n" {
+		t.Fatalf("Golden file for subtest was not written correctly: %q %v", data, err)
+	}
+
+	t.Setenv("IS_UPDATE_GOLDEN", "")
+	result = runGolden(t, dir, func(is Is) { is.Golden("value", value) })
+	if result.Failed {
+		t.Fatalf("Golden failed for an equal value: %s", result.FailMessage)
+	}
+
+	result = runGolden(t, dir, func(is Is) { is.Golden("value", goldenValue{Name: "b", Count: 1}) })
+	if !result.Failed || !errors.Is(result.TestError, errGoldenNotEqual) {
+		t.Fatalf("Golden did not fail for a different value: %s", result.FailMessage)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, test := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{"text", "text"},
+		{[]byte("bytes"), "bytes"},
+		{errCalledFail, errCalledFail.Error()},
+		{goldenValue{Name: "a", Count: 1}, "{Name:a Count:1}"},
+	} {
+		if data, err := MarshalText(test.value); err != nil || string(data) != test.expected {
+			t.Fatalf("MarshalText(%#v) = %q, %v; expected %q", test.value, data, err, test.expected)
+		}
+	}
+}
+
+func TestGoldenPath(t *testing.T) {
+	if path := goldenPath("", "TestA/sub:test/..", "name"); path != filepath.Join("testdata", "TestA", "sub_test", "_", "name.golden") {
+		t.Fatalf("Incorrect golden path: %s", path)
+	}
+}
@@ -0,0 +1,224 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"reflect"
+	"strings"
+)
+
+// HTTPClient sends requests to an [http.Handler] using [httptest]. See [Is.HTTP].
+type HTTPClient struct {
+	is      Is
+	handler http.Handler
+}
+
+// HTTPResponse is the response of a request sent using [HTTPClient].
+// Each assertion returns the response so that assertions can be chained.
+// If an assertion fails, the request and response are included in the failure message.
+//
+//	is.HTTP(handler).Get("/users/1").Status(http.StatusOK).JSON(`{"id": 1}`)
+type HTTPResponse struct {
+	is Is
+
+	// Request is the request that was sent.
+	Request *http.Request
+	// Response is the response returned by the handler.
+	Response *http.Response
+	// Body is the body of the response.
+	Body []byte
+
+	requestBody []byte
+}
+
+// HTTP returns a client that sends requests to the given handler.
+func (is Is) HTTP(handler http.Handler) *HTTPClient {
+	return &HTTPClient{is: is, handler: handler}
+}
+
+// Get sends a GET request to target.
+func (c *HTTPClient) Get(target string) *HTTPResponse {
+	c.is.t().Helper()
+	return c.Request(http.MethodGet, target, nil)
+}
+
+// Post sends a POST request to target with the given content type and body.
+func (c *HTTPClient) Post(target, contentType string, body io.Reader) *HTTPResponse {
+	c.is.t().Helper()
+
+	req := httptest.NewRequest(http.MethodPost, target, body)
+	req.Header.Set("Content-Type", contentType)
+	return c.Do(req)
+}
+
+// Request sends a request with the given method, target and body.
+// body may be nil. See [httptest.NewRequest] for the accepted values of target.
+func (c *HTTPClient) Request(method, target string, body io.Reader) *HTTPResponse {
+	c.is.t().Helper()
+	return c.Do(httptest.NewRequest(method, target, body))
+}
+
+// Do sends the given request to the handler.
+func (c *HTTPClient) Do(req *http.Request) *HTTPResponse {
+	t := c.is.t()
+	t.Helper()
+
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(req.Body); err != nil {
+			c.is.fail(errHTTPRequest, fmt.Sprintf("Failed to read request body: %s", err), "%s %s", req.Method, req.URL)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	// the recorder's body is not consumed by Result.
+	return &HTTPResponse{is: c.is, Request: req, Response: rec.Result(), Body: rec.Body.Bytes(), requestBody: requestBody}
+}
+
+// Status checks if the status code of the response is code.
+func (r *HTTPResponse) Status(code int) *HTTPResponse {
+	if r.Response.StatusCode != code {
+		r.is.t().Helper()
+		r.fail(errHTTPStatus, fmt.Sprintf("Status is %d %s not %d %s", r.Response.StatusCode, http.StatusText(r.Response.StatusCode), code, http.StatusText(code)))
+	}
+	return r
+}
+
+// Header checks if the response has a header with the given key and value.
+func (r *HTTPResponse) Header(key, value string) *HTTPResponse {
+	values := r.Response.Header.Values(key)
+	for _, v := range values {
+		if v == value {
+			return r
+		}
+	}
+
+	r.is.t().Helper()
+	if len(values) == 0 {
+		r.fail(errHTTPHeader, fmt.Sprintf("Header %q is not set, expected %q", key, value))
+	} else {
+		r.fail(errHTTPHeader, fmt.Sprintf("Header %q is %q not %q", key, strings.Join(values, ", "), value))
+	}
+	return r
+}
+
+// Cookie checks if the response sets a cookie with the given name and value.
+func (r *HTTPResponse) Cookie(name, value string) *HTTPResponse {
+	for _, cookie := range r.Response.Cookies() {
+		if cookie.Name != name {
+			continue
+		}
+
+		if cookie.Value != value {
+			r.is.t().Helper()
+			r.fail(errHTTPCookie, fmt.Sprintf("Cookie %q is %q not %q", name, cookie.Value, value))
+		}
+		return r
+	}
+
+	r.is.t().Helper()
+	r.fail(errHTTPCookie, fmt.Sprintf("Cookie %q is not set, expected %q", name, value))
+	return r
+}
+
+// Text checks if the body of the response is equal to expected.
+func (r *HTTPResponse) Text(expected string) *HTTPResponse {
+	if body := string(r.Body); body != expected {
+		s := r.is.state()
+		s.t.Helper()
+		r.fail(errHTTPBody, "Body is not equal:\n"+cmpValue(body, expected, s.options))
+	}
+	return r
+}
+
+// JSON checks if the body of the response is semantically equal to expected.
+// expected may be any value accepted by [Is.JSONEq]. Any other value is encoded using [json.Marshal]
+// before being compared. See [Is.JSONEq] for details.
+func (r *HTTPResponse) JSON(expected interface{}) *HTTPResponse {
+	s := r.is.state()
+	s.t.Helper()
+
+	switch expected.(type) {
+	case []byte, string, json.RawMessage, io.Reader:
+	default:
+		data, err := json.Marshal(expected)
+		if err != nil {
+			r.fail(errHTTPBody, fmt.Sprintf("Failed to encode expected value: %s", err))
+			return r
+		}
+		expected = data
+	}
+
+	body, err := decodeJSON(r.Body)
+	if err != nil {
+		r.fail(errHTTPBody, fmt.Sprintf("Body is not valid JSON: %s", err))
+		return r
+	}
+
+	e, err := decodeJSON(expected)
+	if err != nil {
+		r.fail(errHTTPBody, fmt.Sprintf("Invalid expected JSON value: %s", err))
+		return r
+	}
+
+	if diff := jsonDiff(body, e, s.options); diff != "" {
+		r.fail(errHTTPBody, "JSON body is not equal:"+diff)
+	}
+	return r
+}
+
+// Decode decodes the JSON body of the response into a new value with the same type as expected and
+// checks if it is equal to expected. The values are compared using the same options as [Is.Equal].
+func (r *HTTPResponse) Decode(expected interface{}) *HTTPResponse {
+	s := r.is.state()
+	s.t.Helper()
+
+	if expected == nil {
+		r.fail(errUnsupportedType, "Cannot decode the body into a nil value")
+		return r
+	}
+
+	value := reflect.New(reflect.TypeOf(expected))
+	if err := json.Unmarshal(r.Body, value.Interface()); err != nil {
+		r.fail(errHTTPBody, fmt.Sprintf("Failed to decode body into %T: %s", expected, err))
+		return r
+	}
+
+	if v := value.Elem().Interface(); !reflect.DeepEqual(v, expected) {
+		if diff := cmpValue(v, expected, s.options); len(diff) != 0 {
+			r.fail(errHTTPBody, "Decoded body is not equal:\n"+diff)
+		}
+	}
+	return r
+}
+
+// fail fails the test. The request and response are appended to reason.
+func (r *HTTPResponse) fail(err error, reason string) {
+	r.is.t().Helper()
+
+	var b strings.Builder
+	b.WriteString(reason)
+
+	req := r.Request.Clone(r.Request.Context())
+	req.Body = io.NopCloser(bytes.NewReader(r.requestBody))
+	if dump, err := httputil.DumpRequest(req, true); err == nil {
+		fmt.Fprintf(&b, "\n\nRequest:\n%s", dump)
+	}
+
+	resp := *r.Response
+	resp.Body = io.NopCloser(bytes.NewReader(r.Body))
+	if dump, err := httputil.DumpResponse(&resp, true); err == nil {
+		fmt.Fprintf(&b, "\n\nResponse:\n%s", dump)
+	}
+
+	r.is.fail(err, b.String(), "%s %s", r.Request.Method, r.Request.URL)
+}
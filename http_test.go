@@ -0,0 +1,68 @@
+package is
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type httpUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var httpHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/user":
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(httpUser{ID: 1, Name: "user"})
+	case "/echo":
+		io.Copy(w, r.Body)
+	default:
+		http.NotFound(w, r)
+	}
+})
+
+func TestHTTP(t *testing.T) {
+	mustPass(t, func(is Is) {
+		is.HTTP(httpHandler).Get("/user").
+			Status(http.StatusOK).
+			Header("Content-Type", "application/json").
+			Cookie("session", "abc").
+			JSON(`{"name": "user", "id": 1}`).
+			JSON(map[string]interface{}{"id": 1, "name": "user"}).
+			Decode(httpUser{ID: 1, Name: "user"})
+	})
+
+	mustPass(t, func(is Is) {
+		is.HTTP(httpHandler).Post("/echo", "text/plain", strings.NewReader("hello")).Status(http.StatusOK).Text("hello")
+	})
+
+	mustFail(t, errHTTPStatus, func(is Is) { is.HTTP(httpHandler).Get("/missing").Status(http.StatusOK) })
+	mustFail(t, errHTTPHeader, func(is Is) { is.HTTP(httpHandler).Get("/user").Header("Content-Type", "text/plain") })
+	mustFail(t, errHTTPHeader, func(is Is) { is.HTTP(httpHandler).Get("/user").Header("X-Missing", "value") })
+	mustFail(t, errHTTPCookie, func(is Is) { is.HTTP(httpHandler).Get("/user").Cookie("session", "def") })
+	mustFail(t, errHTTPCookie, func(is Is) { is.HTTP(httpHandler).Get("/user").Cookie("missing", "abc") })
+	mustFail(t, errHTTPBody, func(is Is) { is.HTTP(httpHandler).Get("/user").JSON(`{"id": 2, "name": "user"}`) })
+	mustFail(t, errHTTPBody, func(is Is) { is.HTTP(httpHandler).Get("/missing").JSON(`{}`) })
+	mustFail(t, errHTTPBody, func(is Is) { is.HTTP(httpHandler).Get("/user").Decode(httpUser{ID: 2, Name: "user"}) })
+	mustFail(t, errHTTPBody, func(is Is) { is.HTTP(httpHandler).Request(http.MethodPut, "/echo", strings.NewReader("a")).Text("b") })
+}
+
+func TestHTTPDump(t *testing.T) {
+	result := internal.Run(func(t internal.T) {
+		newIs(t, &options{}).HTTP(httpHandler).Post("/echo", "text/plain", strings.NewReader("request body")).Status(http.StatusCreated)
+	})
+
+	message := strings.Join(result.FailMessage, "\n")
+	for _, expected := range []string{"POST /echo", "Request:\nPOST /echo HTTP/1.1", "request body", "Response:\nHTTP/1.1 200 OK"} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("Failure message does not contain %q:\n%s", expected, message)
+		}
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 // T is an interface implemented by [testing.T] and Test.
 type T interface {
 	Helper()
+	Name() string
 	Parallel()
 	Cleanup(f func())
+	Deadline() (deadline time.Time, ok bool)
 	Fatalf(format string, args ...interface{})
 	Logf(format string, args ...interface{})
+	Skipf(format string, args ...interface{})
 
 	Error(v ...interface{})
 	Errorf(format string, args ...interface{})
@@ -23,31 +27,65 @@ var _ T = (*testing.T)(nil)
 
 // Test an implementation of [T] used to test the [is] package
 type Test struct {
+	TestName string
+
 	CleanupFuncs []func()
 	RunTests     []TestFn
 
 	Failed      bool
 	FailMessage []string
 	TestError   error
+
+	Skipped     bool
+	SkipMessage []string
+
+	// parent is the test that started this test using [Test.Run].
+	// The results of a test are also added to its parents.
+	parent *Test
 }
 
 // Helper is a no-op function
 func (t *Test) Helper() {}
 
+// Name returns the name of the test.
+// Tests run using [Test.Run] are named in the same way as [testing.T.Run] names subtests.
+func (t *Test) Name() string { return t.TestName }
+
 // Parallel is a no-op function
 func (t *Test) Parallel() {}
 
+// Deadline returns the zero time and false since [Test] does not have a deadline.
+func (t *Test) Deadline() (time.Time, bool) { return time.Time{}, false }
+
 // Logf is a no-op function
 func (t *Test) Logf(format string, args ...interface{}) {}
 
+// Skipf marks the test as skipped.
+// Unlike [testing.T.Skipf], this does not stop the test.
+func (t *Test) Skipf(format string, args ...interface{}) {
+	for ; t != nil; t = t.parent {
+		t.Skipped = true
+		t.SkipMessage = append(t.SkipMessage, fmt.Sprintf(format, args...))
+	}
+}
+
 // Cleanup registers a cleanup function
-func (t *Test) Cleanup(f func()) { t.CleanupFuncs = append(t.CleanupFuncs, f) }
+func (t *Test) Cleanup(f func()) {
+	for ; t != nil; t = t.parent {
+		t.CleanupFuncs = append(t.CleanupFuncs, f)
+	}
+}
 
-// Run adds a test to be run
+// Run runs f using a new Test so that the result of each subtest can be checked separately.
+// The results of the subtest are also added to t.
 func (t *Test) Run(name string, parallel bool, f func(t *Test)) bool {
-	t.RunTests = append(t.RunTests, TestFn{Name: name, Parallel: parallel, F: f})
-	f(t)
-	return true
+	for p := t; p != nil; p = p.parent {
+		p.RunTests = append(p.RunTests, TestFn{Name: name, Parallel: parallel, F: f})
+	}
+
+	sub := &Test{TestName: t.TestName + "/" + name, parent: t}
+	f(sub)
+	return !sub.Failed
 }
 
 // TestFn a test function passed to [Test.Run]
@@ -61,21 +99,22 @@ var errFatal = errors.New("fatal")
 
 // Fatalf fails the test and panics
 func (t *Test) Fatalf(format string, args ...interface{}) {
-	t.Failed = true
-	t.FailMessage = append(t.FailMessage, fmt.Sprintf(format, args...))
+	t.Errorf(format, args...)
 	panic(errFatal)
 }
 
 // Error appends an error
-func (t *Test) Error(v ...interface{}) {
-	t.Failed = true
-	t.FailMessage = append(t.FailMessage, fmt.Sprint(v...))
-}
+func (t *Test) Error(v ...interface{}) { t.fail(fmt.Sprint(v...)) }
 
 // Errorf appends an error
-func (t *Test) Errorf(format string, args ...interface{}) {
-	t.Failed = true
-	t.FailMessage = append(t.FailMessage, fmt.Sprintf(format, args...))
+func (t *Test) Errorf(format string, args ...interface{}) { t.fail(fmt.Sprintf(format, args...)) }
+
+// fail marks the test and its parents as failed.
+func (t *Test) fail(msg string) {
+	for ; t != nil; t = t.parent {
+		t.Failed = true
+		t.FailMessage = append(t.FailMessage, msg)
+	}
 }
 
 // FailNow panic with errFatal
@@ -99,5 +138,7 @@ func Run(f func(T)) (t *Test) {
 
 // SetError sets the error that occurred
 func (t *Test) SetError(err error) {
-	t.TestError = err
+	for ; t != nil; t = t.parent {
+		t.TestError = err
+	}
 }
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/yehan2002/is/v2/internal"
 )
@@ -25,9 +28,17 @@ func (is Is) Equal(value, expected interface{}, format string, i ...interface{})
 
 // Fail immediately fails the test.
 // Calling this function is the equivalent of calling is.T().Fatalf.
+// Fail always stops the test, even if is was created using [Is.Soft] or [NonFatal].
 func (is Is) Fail(format string, args ...interface{}) {
-	is.t().Helper()
-	is.fail(errCalledFail, "", format, args...)
+	defer is.state().checkTimeout()()
+	t := is.t()
+	t.Helper()
+
+	if internal, ok := t.(*internal.Test); ok {
+		internal.SetError(errCalledFail)
+	}
+
+	t.Fatalf(format, args...)
 }
 
 // Err checks if any error in err's chain matches target.
@@ -39,49 +50,171 @@ func (is Is) Err(err, target error, format string, args ...interface{}) {
 	}
 }
 
+// NoErr checks if err is nil.
+// If err is not nil the test fails and every error in err's chain is reported.
+func (is Is) NoErr(err error, format string, args ...interface{}) {
+	if err != nil {
+		is.t().Helper()
+		is.fail(errUnexpectedError, "Unexpected error:"+errChain(err), format, args...)
+	}
+}
+
+// ErrAs checks if any error in err's chain can be assigned to target using [errors.As].
+// target must be a non-nil pointer to a type that implements error or to an interface type.
+// If a matching error is found target is set to the error. Otherwise the test fails.
+func (is Is) ErrAs(err error, target interface{}, format string, args ...interface{}) {
+	if !errors.As(err, target) {
+		is.t().Helper()
+		is.fail(errErrorNotAs, fmt.Sprintf("Error `%s` is not `%s`", err, reflect.TypeOf(target).Elem()), format, args...)
+	}
+}
+
+// ErrContains checks if err is not nil and the message of err contains substr.
+func (is Is) ErrContains(err error, substr string, format string, args ...interface{}) {
+	if err == nil || !strings.Contains(err.Error(), substr) {
+		is.t().Helper()
+		is.fail(errErrorNotContain, fmt.Sprintf("Error `%s` does not contain `%s`", err, substr), format, args...)
+	}
+}
+
+// ErrMatches checks if err is not nil and the message of err matches the regular expression pattern.
+func (is Is) ErrMatches(err error, pattern string, format string, args ...interface{}) {
+	re, compileErr := regexp.Compile(pattern)
+	if compileErr != nil {
+		is.t().Helper()
+		is.fail(errErrorNotMatchPattern, fmt.Sprintf("Invalid pattern `%s`: %s", pattern, compileErr), format, args...)
+		return
+	}
+
+	if err == nil || !re.MatchString(err.Error()) {
+		is.t().Helper()
+		is.fail(errErrorNotMatchPattern, fmt.Sprintf("Error `%s` does not match `%s`", err, pattern), format, args...)
+	}
+}
+
 // Panic checks if calling the given function causes a panic.
 // If the given function does not panic the test fails.
 func (is Is) Panic(fn func(), format string, i ...interface{}) {
+	is.t().Helper()
+	is.catchPanic(fn, format, i...)
+}
 
-	var recovered bool
+// PanicWith checks if calling the given function panics with a value equal to expected.
+// The recovered value is compared using the same options as [Is.Equal].
+// If both the recovered value and expected are errors, they are also considered equal if the recovered
+// error matches expected using [errors.Is].
+func (is Is) PanicWith(fn func(), expected interface{}, format string, i ...interface{}) {
+	s := is.state()
+	s.t.Helper()
 
-	func() {
-		defer func() {
-			if r := recover(); r != nil {
-				recovered = true
-			}
-		}()
-		fn()
+	value, ok := is.catchPanic(fn, format, i...)
+	if !ok {
+		return
+	}
+
+	if expectedErr, isErr := expected.(error); isErr {
+		if err, isErr := value.(error); isErr && errors.Is(err, expectedErr) {
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(value, expected) {
+		if diff := cmpValue(value, expected, s.options); len(diff) != 0 {
+			is.fail(errPanicNotEqual, "Recovered value is not equal:\n"+diff, format, i...)
+		}
+	}
+}
+
+// PanicMatches checks if calling the given function panics with a value that matches the regular
+// expression pattern. The recovered value is formatted using [fmt.Sprint] before matching.
+func (is Is) PanicMatches(fn func(), pattern string, format string, i ...interface{}) {
+	is.t().Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		is.fail(errPanicNotMatch, fmt.Sprintf("Invalid pattern `%s`: %s", pattern, err), format, i...)
 		return
-	}()
+	}
+
+	value, ok := is.catchPanic(fn, format, i...)
+	if ok && !re.MatchString(fmt.Sprint(value)) {
+		is.fail(errPanicNotMatch, fmt.Sprintf("Recovered value `%v` does not match `%s`", value, pattern), format, i...)
+	}
+}
+
+// NotPanic checks if calling the given function returns normally.
+// If the function panics the test fails and the recovered value and stack trace are reported.
+func (is Is) NotPanic(fn func(), format string, i ...interface{}) {
+	r := callRecover(fn)
+	switch {
+	case r.goexit:
+		is.t().Helper()
+		is.fail(errFuncGoexit, "Function called runtime.Goexit", format, i...)
+	case r.panicked:
+		is.t().Helper()
+		is.fail(errFuncPanic, fmt.Sprintf("Function panicked: %v\n%s", r.value, r.stack), format, i...)
+	}
+}
 
-	if !recovered {
+// catchPanic calls fn and fails the test if fn did not panic.
+// This returns the recovered value and true if fn panicked.
+func (is Is) catchPanic(fn func(), format string, i ...interface{}) (interface{}, bool) {
+	r := callRecover(fn)
+	switch {
+	case r.goexit:
+		is.t().Helper()
+		is.fail(errFuncGoexit, "Function called runtime.Goexit instead of panicking", format, i...)
+	case !r.panicked:
 		is.t().Helper()
 		is.fail(errFuncNoPanic, "Function did not panic", format, i...)
 	}
+
+	return r.value, r.panicked
 }
 
 // Log logs the given message.
 // This is the equivalent of calling is.T().Log(msg).
 // This function can be called from multiple goroutines concurrently.
 func (is Is) Log(msg string, i ...interface{}) {
+	defer is.state().checkTimeout()()
 	t := is.t()
 	t.Helper()
 	t.Logf(msg, i...)
 }
 
+// Soft returns a copy of is that does not stop the test when a check fails.
+// Failed checks are reported using is.T().Errorf and the test continues running.
+// A summary of every failed check and its call site is logged when the test completes.
+// Subtests started using [Is.Run] and [Is.RunP] on the returned value are also non-fatal.
+func (is Is) Soft() Is {
+	s := is.state()
+	opts := s.options.clone()
+	opts.nonFatal = true
+	return newState(s.t, opts, s.test).Is
+}
+
 // Run runs the given sub test.
 // This runs testFn in a separate goroutine and blocks until f returns or calls is.T().Parallel to become a
 // parallel test.
 func (is Is) Run(name string, testFn func(Is)) {
 	s := is.state()
-	runT(s.t, s.options, name, false, testFn)
+	s.checkTimeout()()
+	runT(s.t, s.test, s.options, name, false, s.options.timeout, testFn)
 }
 
 // RunP runs the given test in parallel with the current test.
 func (is Is) RunP(name string, testFn func(Is)) {
 	s := is.state()
-	runT(s.t, s.options, name, true, testFn)
+	s.checkTimeout()()
+	runT(s.t, s.test, s.options, name, true, s.options.timeout, testFn)
+}
+
+// RunTimeout is like [Is.Run] but the sub test fails if it does not complete within the given timeout.
+// This overrides the timeout set by the [Timeout] option. See [Timeout] for details.
+func (is Is) RunTimeout(name string, timeout time.Duration, testFn func(Is)) {
+	s := is.state()
+	s.checkTimeout()()
+	runT(s.t, s.test, s.options, name, false, timeout, testFn)
 }
 
 // state gets the underlying *state for this test.
@@ -96,16 +229,21 @@ func (is Is) state() (s *state) {
 func (is Is) t() internal.T { return is.state().t }
 
 // T gets the underlying *testing.T for this test.
+// Inside functions passed to [Is.EventuallyWith] and [Is.ConsistentlyWith] this returns the *testing.T of
+// the test. Failures reported directly using the returned value are not recorded by these functions.
 func (is Is) T() *testing.T {
+	t := unwrapT(is.state().t)
+
 	// this will panic when testing this package because T will be [*internal.Test] not [*testing.T].
-	return is.state().t.(*testing.T)
+	return t.(*testing.T)
 }
 
 // fail fails the test.
-// Calling this function will cause the test to stop executing.
+// Calling this function will cause the test to stop executing unless the test is non-fatal.
 // reason is the reason the test failed. format and i are user provided information about why the
 // test failed. The error value passed to this function is only used when testing this package.
 func (is Is) fail(err error, reason string, format string, i ...interface{}) {
+	defer is.state().checkTimeout()()
 	t := is.t()
 	t.Helper()
 
@@ -119,6 +257,11 @@ func (is Is) fail(err error, reason string, format string, i ...interface{}) {
 		t.Error(reason)
 	}
 
+	if s := is.state(); s.soft != nil {
+		s.soft.add(reason, format, i...)
+		return
+	}
+
 	t.FailNow()
 }
 
@@ -130,6 +273,12 @@ func New(t *testing.T, opts ...Option) Is {
 type state struct {
 	t       internal.T
 	options *options
+
+	// test is shared by all states of the same test.
+	test *testInfo
+
+	// soft is the log of failed checks. This is only set if the test is non-fatal.
+	soft *softLog
 }
 
 func (i *state) Is(cond bool, msg string, fmt ...interface{}) {
@@ -144,17 +293,50 @@ func (i *state) Is(cond bool, msg string, fmt ...interface{}) {
 			}
 		}
 
+		defer i.checkTimeout()()
+
 		// set the error. This value is used by tests to check if the test failed for the correct reason.
 		if internal, ok := i.t.(*internal.Test); ok {
 			internal.SetError(errCondition)
 		}
 
+		if i.soft != nil {
+			i.t.Errorf(msg, fmt...)
+			i.soft.add("", msg, fmt...)
+			return
+		}
+
 		i.t.Fatalf(msg, fmt...)
 	}
 }
 
+// newIs creates an Is for a new top level test.
 func newIs(t internal.T, opts *options) Is {
-	s := state{t: t, options: opts}
+	return newTest(t, nil, opts, false, 0)
+}
+
+// newTest creates an Is for a new test.
+// parent is the test that started this test, or nil if this is a top level test. parallel is set if
+// the test is a parallel test. timeout is the timeout of this test or zero if the test has no timeout.
+// This starts all checks that are run once per test.
+func newTest(t internal.T, parent *testInfo, opts *options, parallel bool, timeout time.Duration) Is {
+	info := newTestInfo(t, parent, parallel, timeout)
+	if opts.detectLeaks {
+		detectLeaks(t, opts, info)
+	}
+
+	return newState(t, opts, info).Is
+}
+
+// newState creates a new state for t.
+// Unlike [newTest] this can be used to create multiple states for the same test.
+func newState(t internal.T, opts *options, test *testInfo) *state {
+	s := &state{t: t, options: opts, test: test}
+
+	if opts.nonFatal {
+		s.soft = &softLog{}
+		t.Cleanup(func() { s.soft.report(t) })
+	}
 
-	return s.Is
+	return s
 }
@@ -2,7 +2,9 @@ package is
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -84,8 +86,46 @@ func TestIs(t *testing.T) {
 	mustFail(t, errErrorNotMatch, func(is Is) { is.Err(errCalledFail, os.ErrClosed, "this should fail") })
 	mustPass(t, func(is Is) { is.Err(os.ErrClosed, os.ErrClosed, "this should pass") })
 
+	mustFail(t, errUnexpectedError, func(is Is) { is.NoErr(fmt.Errorf("wrapped: %w", os.ErrClosed), "this should fail") })
+	mustPass(t, func(is Is) { is.NoErr(nil, "this should pass") })
+
+	mustFail(t, errErrorNotAs, func(is Is) {
+		var target *os.PathError
+		is.ErrAs(os.ErrClosed, &target, "this should fail")
+	})
+	mustPass(t, func(is Is) {
+		var target *os.PathError
+		is.ErrAs(fmt.Errorf("wrapped: %w", &os.PathError{Op: "open"}), &target, "this should pass")
+		is.Equal(target.Op, "open", "target should be set")
+	})
+
+	mustFail(t, errErrorNotContain, func(is Is) { is.ErrContains(nil, "closed", "this should fail") })
+	mustFail(t, errErrorNotContain, func(is Is) { is.ErrContains(os.ErrClosed, "open", "this should fail") })
+	mustPass(t, func(is Is) { is.ErrContains(os.ErrClosed, "closed", "this should pass") })
+
+	mustFail(t, errErrorNotMatchPattern, func(is Is) { is.ErrMatches(nil, "closed", "this should fail") })
+	mustFail(t, errErrorNotMatchPattern, func(is Is) { is.ErrMatches(os.ErrClosed, "[", "this should fail") })
+	mustFail(t, errErrorNotMatchPattern, func(is Is) { is.ErrMatches(os.ErrClosed, "^open", "this should fail") })
+	mustPass(t, func(is Is) { is.ErrMatches(os.ErrClosed, "already clo.ed$", "this should pass") })
+
 	mustFail(t, errFuncNoPanic, func(is Is) { is.Panic(func() {}, "this should fail") })
 	mustPass(t, func(is Is) { is.Panic(func() { panic("err") }, "this should pass") })
+	mustFail(t, errFuncGoexit, func(is Is) { is.Panic(runtime.Goexit, "this should fail") })
+
+	mustFail(t, errFuncNoPanic, func(is Is) { is.PanicWith(func() {}, "err", "this should fail") })
+	mustFail(t, errPanicNotEqual, func(is Is) { is.PanicWith(func() { panic("err") }, "error", "this should fail") })
+	mustPass(t, func(is Is) { is.PanicWith(func() { panic("err") }, "err", "this should pass") })
+	mustPass(t, func(is Is) {
+		is.PanicWith(func() { panic(fmt.Errorf("wrapped: %w", os.ErrClosed)) }, os.ErrClosed, "this should pass")
+	})
+
+	mustFail(t, errFuncNoPanic, func(is Is) { is.PanicMatches(func() {}, "err", "this should fail") })
+	mustFail(t, errPanicNotMatch, func(is Is) { is.PanicMatches(func() { panic("err") }, "^rr", "this should fail") })
+	mustPass(t, func(is Is) { is.PanicMatches(func() { panic(os.ErrClosed) }, "closed$", "this should pass") })
+
+	mustFail(t, errFuncPanic, func(is Is) { is.NotPanic(func() { panic("err") }, "this should fail") })
+	mustFail(t, errFuncGoexit, func(is Is) { is.NotPanic(runtime.Goexit, "this should fail") })
+	mustPass(t, func(is Is) { is.NotPanic(func() {}, "this should pass") })
 
 	mustPass(t, func(is Is) {
 		is.Log("test")
@@ -0,0 +1,219 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPointerEscaper escapes object keys used in JSON pointers.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONEq checks if value and expected are semantically equal JSON documents.
+// Both values may be a []byte, string, [json.RawMessage] or [io.Reader] containing JSON.
+// The documents are decoded before being compared, so whitespace and the order of object keys are ignored.
+// Differences are reported using JSON Pointer paths.
+//
+// [EquateEmpty] causes null to be equal to empty arrays and objects.
+// [EquateApprox] and [EquateULP] are used when comparing numbers.
+func (is Is) JSONEq(value, expected interface{}, format string, args ...interface{}) {
+	s := is.state()
+	s.t.Helper()
+
+	v, err := decodeJSON(value)
+	if err != nil {
+		is.fail(errInvalidJSON, fmt.Sprintf("Invalid JSON value: %s", err), format, args...)
+		return
+	}
+
+	e, err := decodeJSON(expected)
+	if err != nil {
+		is.fail(errInvalidJSON, fmt.Sprintf("Invalid expected JSON value: %s", err), format, args...)
+		return
+	}
+
+	if diff := jsonDiff(v, e, s.options); diff != "" {
+		is.fail(errJSONNotEqual, "JSON values are not equal:"+diff, format, args...)
+	}
+}
+
+// decodeJSON decodes the JSON document contained in v.
+// v must be a []byte, string, [json.RawMessage] or [io.Reader].
+// Numbers are decoded as [json.Number] to avoid losing precision.
+func decodeJSON(v interface{}) (interface{}, error) {
+	var r io.Reader
+	switch v := v.(type) {
+	case json.RawMessage:
+		r = bytes.NewReader(v)
+	case []byte:
+		r = bytes.NewReader(v)
+	case string:
+		r = strings.NewReader(v)
+	case io.Reader:
+		r = v
+	default:
+		return nil, fmt.Errorf("cannot read JSON from %T", v)
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+
+	return decoded, nil
+}
+
+// jsonDiff compares two decoded JSON values.
+// This returns a list of differences, one per line, or an empty string if the values are equal.
+func jsonDiff(value, expected interface{}, options *options) string {
+	var b strings.Builder
+	jsonDiffValue(&b, "", value, expected, options)
+	return b.String()
+}
+
+func jsonDiffValue(b *strings.Builder, path string, value, expected interface{}, options *options) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		if v, ok := value.(map[string]interface{}); ok {
+			jsonDiffObject(b, path, v, e, options)
+			return
+		}
+	case []interface{}:
+		if v, ok := value.([]interface{}); ok {
+			jsonDiffArray(b, path, v, e, options)
+			return
+		}
+	case json.Number:
+		if v, ok := value.(json.Number); ok && jsonNumberEqual(v, e, options) {
+			return
+		}
+	default:
+		if reflect.DeepEqual(value, expected) {
+			return
+		}
+	}
+
+	if options.equateEmpty && (isEmptyJSON(value) && expected == nil || value == nil && isEmptyJSON(expected)) {
+		return
+	}
+
+	fmt.Fprintf(b, "\n\t%s: expected %s, got %s", jsonPath(path), jsonString(expected), jsonString(value))
+}
+
+func jsonDiffObject(b *strings.Builder, path string, value, expected map[string]interface{}, options *options) {
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range value {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + jsonPointerEscaper.Replace(key)
+
+		v, okV := value[key]
+		e, okE := expected[key]
+		switch {
+		case !okV:
+			fmt.Fprintf(b, "\n\t%s: missing, expected %s", keyPath, jsonString(e))
+		case !okE:
+			fmt.Fprintf(b, "\n\t%s: unexpected value %s", keyPath, jsonString(v))
+		default:
+			jsonDiffValue(b, keyPath, v, e, options)
+		}
+	}
+}
+
+func jsonDiffArray(b *strings.Builder, path string, value, expected []interface{}, options *options) {
+	for i := 0; i < len(value) || i < len(expected); i++ {
+		indexPath := path + "/" + strconv.Itoa(i)
+		switch {
+		case i >= len(value):
+			fmt.Fprintf(b, "\n\t%s: missing, expected %s", indexPath, jsonString(expected[i]))
+		case i >= len(expected):
+			fmt.Fprintf(b, "\n\t%s: unexpected value %s", indexPath, jsonString(value[i]))
+		default:
+			jsonDiffValue(b, indexPath, value[i], expected[i], options)
+		}
+	}
+}
+
+// jsonNumberEqual compares two JSON numbers.
+// Numbers are compared exactly so that large integers that cannot be represented by a float64 are not
+// considered equal. If [EquateApprox] or [EquateULP] is set, numbers are also compared as float64 values
+// using these options.
+func jsonNumberEqual(value, expected json.Number, options *options) bool {
+	if value == expected {
+		return true
+	}
+
+	v, okV := new(big.Rat).SetString(string(value))
+	e, okE := new(big.Rat).SetString(string(expected))
+	if okV && okE && v.Cmp(e) == 0 {
+		return true
+	}
+
+	if !options.approx && options.ulp == 0 {
+		return false
+	}
+	return jsonFloatEqual(value, expected, options)
+}
+
+// jsonFloatEqual compares two JSON numbers as float64 values using the options set by [EquateApprox] and
+// [EquateULP].
+func jsonFloatEqual(value, expected json.Number, options *options) bool {
+	v, errV := value.Float64()
+	e, errE := expected.Float64()
+	if errV != nil || errE != nil {
+		return false
+	}
+
+	return options.floatPartEqual(v, e, false)
+}
+
+// isEmptyJSON checks if v is an empty JSON array or object.
+func isEmptyJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// jsonPath formats a JSON pointer for display.
+func jsonPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// jsonString formats a decoded JSON value.
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
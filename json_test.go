@@ -0,0 +1,73 @@
+package is
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestJSONEq(t *testing.T) {
+	mustPass(t, func(is Is) { is.JSONEq(`{"a": 1, "b": [1, 2]}`, []byte(`{"b":[1,2],"a":1.0}`), "this should pass") })
+	mustPass(t, func(is Is) {
+		is.JSONEq(strings.NewReader(`"a"`), json.RawMessage(`  "a"  `), "this should pass")
+	})
+	mustFail(t, errJSONNotEqual, func(is Is) { is.JSONEq(`{"a": 1}`, `{"a": 2}`, "this should fail") })
+	mustFail(t, errJSONNotEqual, func(is Is) { is.JSONEq(`[1, 2]`, `[1]`, "this should fail") })
+	mustFail(t, errJSONNotEqual, func(is Is) { is.JSONEq(`null`, `[]`, "this should fail") })
+	mustFail(t, errInvalidJSON, func(is Is) { is.JSONEq(`{`, `{}`, "this should fail") })
+	mustFail(t, errInvalidJSON, func(is Is) { is.JSONEq(`{}`, `{} {}`, "this should fail") })
+	mustFail(t, errInvalidJSON, func(is Is) { is.JSONEq(1, `{}`, "this should fail") })
+}
+
+func TestJSONEqOptions(t *testing.T) {
+	run := func(value, expected string, opts ...Option) *internal.Test {
+		return internal.Run(func(t internal.T) { newIs(t, newOptions(opts)).JSONEq(value, expected, "") })
+	}
+
+	if result := run(`{"a": null}`, `{"a": []}`); result.Failed {
+		t.Fatalf("null and [] were not considered equal with EquateEmpty: %s", result.FailMessage)
+	}
+
+	if result := run(`{"a": 1.001}`, `{"a": 1}`); !result.Failed {
+		t.Fatal("Different numbers were considered equal")
+	}
+
+	// these numbers cannot be represented exactly by a float64.
+	if result := run(`{"id": 9007199254740993}`, `{"id": 9007199254740992}`); !result.Failed {
+		t.Fatal("Different large integers were considered equal")
+	}
+
+	if result := run(`{"a": 1e2, "b": 0.50}`, `{"a": 100, "b": 5e-1}`); result.Failed {
+		t.Fatalf("Equal numbers with different formatting were not considered equal: %s", result.FailMessage)
+	}
+
+	if result := run(`{"a": 1.001}`, `{"a": 1}`, EquateApprox(0.01, 0)); result.Failed {
+		t.Fatalf("Numbers were not considered equal with EquateApprox: %s", result.FailMessage)
+	}
+}
+
+func TestJSONEqPaths(t *testing.T) {
+	result := internal.Run(func(t internal.T) {
+		newIs(t, newOptions(nil)).JSONEq(
+			`{"items": [{"price": 2}, {}], "a/b": true, "extra": 1}`,
+			`{"items": [{"price": 1.5}, {"name": "x"}, 3], "a/b": true}`, "")
+	})
+
+	message := strings.Join(result.FailMessage, "\n")
+	for _, expected := range []string{
+		"/items/0/price: expected 1.5, got 2",
+		"/items/1/name: missing, expected \"x\"",
+		"/items/2: missing, expected 3",
+		"/extra: unexpected value 1",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("Failure message does not contain %q:\n%s", expected, message)
+		}
+	}
+
+	if strings.Contains(message, "a~1b") {
+		t.Fatalf("Equal values were reported:\n%s", message)
+	}
+}
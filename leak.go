@@ -0,0 +1,287 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// defaultLeakGrace is the time goroutines started by a test have to exit after the test completes.
+const defaultLeakGrace = time.Second
+
+// ignoredGoroutines are functions that start goroutines which are never considered leaks.
+var ignoredGoroutines = []string{"testing.tRunner", "testing.(*T).Run", "os/signal.signal_recv"}
+
+// DetectLeaks fails the test if goroutines started by the test are still running after it completes.
+// A snapshot of all goroutines is taken when the test starts. When the test completes, goroutines
+// started by the test have a short grace period to exit. The stacks of goroutines that are still
+// running are reported, grouped by the location they were created at.
+//
+// Subtests started using [Is.Run] and [Is.RunP] and each test in a suite are checked separately.
+// In parallel tests, goroutines that cannot be traced back to the test, such as goroutines started by a
+// goroutine that has already exited, are not reported since they may have been started by another test.
+// Go 1.21 or later is required to trace goroutines. Otherwise every goroutine started while the test
+// was running is checked, including goroutines started by other parallel tests.
+// Use [IgnoreGoroutines] to ignore known background goroutines.
+func DetectLeaks() Option {
+	return func(o *options) { o.detectLeaks = true }
+}
+
+// IgnoreGoroutines sets functions that are ignored by [DetectLeaks].
+// A goroutine is ignored if any function in its stack has one of the given names.
+// Names must be fully qualified, for example "net/http.(*persistConn).readLoop".
+func IgnoreGoroutines(funcs ...string) Option {
+	return func(o *options) { o.ignoredGoroutines = append(o.ignoredGoroutines, funcs...) }
+}
+
+// LeakGrace sets the time goroutines started by a test have to exit after the test completes before
+// they are reported by [DetectLeaks]. A zero grace period uses the default of one second.
+func LeakGrace(grace time.Duration) Option {
+	return func(o *options) { o.leakGrace = grace }
+}
+
+// reportedLeaks contains the ids of goroutines that have already been reported as leaks.
+// This prevents a goroutine leaked by a subtest from also being reported by its parent.
+var reportedLeaks sync.Map
+
+// goroutine is a goroutine parsed from the output of [runtime.Stack].
+type goroutine struct {
+	id     int
+	parent int // the id of the goroutine that created this goroutine, or 0 if unknown.
+	funcs  []string
+
+	// createdBy is the function and location that created the goroutine.
+	createdBy string
+	stack     string
+}
+
+// detectLeaks takes a snapshot of the running goroutines and registers a cleanup function that
+// reports goroutines that were started by t and are still running. info is the information of t.
+func detectLeaks(t internal.T, opts *options, info *testInfo) {
+	testID := currentGoroutine()
+	before := map[int]bool{}
+	for _, g := range goroutines() {
+		before[g.id] = true
+	}
+
+	grace := opts.leakGrace
+	if grace == 0 {
+		grace = defaultLeakGrace
+	}
+
+	t.Cleanup(func() {
+		var leaked []goroutine
+		poll(grace, 10*time.Millisecond, true, func() bool {
+			leaked = findLeaks(info.testGoroutines(testID), before, info.isParallel(), opts.ignoredGoroutines)
+			return len(leaked) == 0
+		})
+
+		if len(leaked) != 0 {
+			for _, g := range leaked {
+				reportedLeaks.Store(g.id, true)
+			}
+
+			t.Helper()
+			if internal, ok := t.(*internal.Test); ok {
+				internal.SetError(errGoroutineLeak)
+			}
+
+			t.Errorf("%s", formatLeaks(leaked))
+		}
+	})
+}
+
+// findLeaks returns the goroutines that were started by the test running in the goroutines test and
+// are not included in before. parallel is set if the test is a parallel test.
+func findLeaks(test, before map[int]bool, parallel bool, ignored []string) (leaked []goroutine) {
+	all := goroutines()
+	current := currentGoroutine()
+
+	byID := make(map[int]*goroutine, len(all))
+	for i := range all {
+		byID[all[i].id] = &all[i]
+	}
+
+	for _, g := range all {
+		if before[g.id] || g.id == current || g.hasFunc(ignoredGoroutines) || g.hasFunc(ignored) {
+			continue
+		}
+
+		if _, reported := reportedLeaks.Load(g.id); reported {
+			continue
+		}
+
+		if g.startedBy(test, before, parallel, byID) {
+			leaked = append(leaked, g)
+		}
+	}
+
+	return leaked
+}
+
+// formatLeaks formats leaked goroutines grouped by the location they were created at.
+func formatLeaks(leaked []goroutine) string {
+	groups := map[string][]goroutine{}
+	var sites []string
+	for _, g := range leaked {
+		if _, ok := groups[g.createdBy]; !ok {
+			sites = append(sites, g.createdBy)
+		}
+		groups[g.createdBy] = append(groups[g.createdBy], g)
+	}
+	sort.Strings(sites)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "is: found %d leaked goroutine(s)", len(leaked))
+	for _, site := range sites {
+		fmt.Fprintf(&b, "\n\n%d goroutine(s) created by %s\n%s", len(groups[site]), site, groups[site][0].stack)
+	}
+	return b.String()
+}
+
+// startedBy checks if g was started by one of the goroutines running the test or by a goroutine started
+// by them. g is assumed to be started by the test if the runtime does not report the parent of goroutines.
+// If a goroutine in the chain has already exited, g is assumed to be started by the test unless the test
+// is parallel, since goroutines started by tests running in parallel would be reported as leaks otherwise.
+func (g *goroutine) startedBy(test, before map[int]bool, parallel bool, byID map[int]*goroutine) bool {
+	if g.parent == 0 {
+		return true
+	}
+
+	for parent := g.parent; parent != 0; {
+		if test[parent] {
+			return true
+		}
+
+		// the chain reached a goroutine that was running before the test started.
+		if before[parent] {
+			return false
+		}
+
+		p, ok := byID[parent]
+		if !ok {
+			break
+		}
+		parent = p.parent
+	}
+	return !parallel
+}
+
+// addGoroutine records that the calling goroutine is running the test.
+func (i *testInfo) addGoroutine() {
+	id := currentGoroutine()
+
+	i.goroutinesMu.Lock()
+	defer i.goroutinesMu.Unlock()
+
+	if i.goroutines == nil {
+		i.goroutines = map[int]bool{}
+	}
+	i.goroutines[id] = true
+}
+
+// testGoroutines returns the ids of the goroutines that run the test. testID is the goroutine of the test.
+func (i *testInfo) testGoroutines(testID int) map[int]bool {
+	i.goroutinesMu.Lock()
+	defer i.goroutinesMu.Unlock()
+
+	ids := map[int]bool{testID: true}
+	for id := range i.goroutines {
+		ids[id] = true
+	}
+	return ids
+}
+
+// hasFunc checks if any function in the stack of g has one of the given names.
+func (g *goroutine) hasFunc(names []string) bool {
+	for _, fn := range g.funcs {
+		for _, name := range names {
+			if fn == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goroutines returns all running goroutines.
+func goroutines() []goroutine {
+	var all []goroutine
+	for _, block := range bytes.Split(stacks(true), []byte("\n\n")) {
+		if g, ok := parseGoroutine(string(block)); ok {
+			all = append(all, g)
+		}
+	}
+	return all
+}
+
+// currentGoroutine returns the id of the calling goroutine.
+func currentGoroutine() int {
+	g, _ := parseGoroutine(string(stacks(false)))
+	return g.id
+}
+
+// parseGoroutine parses the stack of a single goroutine.
+//
+//	goroutine 7 [chan receive]:
+//	main.worker(...)
+//		/path/main.go:12 +0x1d
+//	created by main.start in goroutine 1
+//		/path/main.go:20 +0x25
+func parseGoroutine(stack string) (g goroutine, ok bool) {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "goroutine ") {
+		return g, false
+	}
+
+	fields := strings.Fields(lines[0])
+	if len(fields) < 2 {
+		return g, false
+	}
+
+	var err error
+	if g.id, err = strconv.Atoi(fields[1]); err != nil {
+		return g, false
+	}
+
+	g.stack = stack
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "created by ") {
+			creator := strings.TrimPrefix(line, "created by ")
+			if j := strings.Index(creator, " in goroutine "); j != -1 {
+				g.parent, _ = strconv.Atoi(creator[j+len(" in goroutine "):])
+				creator = creator[:j]
+			}
+
+			g.funcs = append(g.funcs, creator)
+			g.createdBy = creator
+			if i+1 < len(lines) {
+				location := strings.TrimSpace(lines[i+1])
+				if j := strings.LastIndex(location, " +0x"); j != -1 {
+					location = location[:j]
+				}
+				g.createdBy += " at " + location
+			}
+			break
+		}
+
+		// remove the arguments from the function name
+		if j := strings.LastIndexByte(line, '('); j != -1 {
+			line = line[:j]
+		}
+		g.funcs = append(g.funcs, line)
+	}
+
+	return g, true
+}
@@ -0,0 +1,166 @@
+package is
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func leakyWorker(stop chan struct{}) { <-stop }
+
+func runLeakTest(t *testing.T, fn func(is Is), opts ...Option) *internal.Test {
+	t.Helper()
+	o := newOptions(append(opts, DetectLeaks(), LeakGrace(50*time.Millisecond)))
+
+	return internal.Run(func(t internal.T) {
+		fn(newIs(t, o))
+		for _, cleanup := range t.(*internal.Test).CleanupFuncs {
+			cleanup()
+		}
+	})
+}
+
+func TestDetectLeaks(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	result := runLeakTest(t, func(is Is) { go leakyWorker(stop) })
+	if !result.Failed || !errors.Is(result.TestError, errGoroutineLeak) {
+		t.Fatal("Leaked goroutine was not detected")
+	}
+
+	message := strings.Join(result.FailMessage, "\n")
+	if !strings.Contains(message, "1 goroutine(s) created by") || !strings.Contains(message, "leakyWorker") {
+		t.Fatalf("Incorrect failure message: %s", message)
+	}
+
+	// goroutines started by a goroutine started by the test are also leaks.
+	result = runLeakTest(t, func(is Is) {
+		started := make(chan struct{})
+		go func() { go leakyWorker(stop); close(started) }()
+		<-started
+	})
+	if !result.Failed {
+		t.Fatal("Leaked goroutine was not detected")
+	}
+
+	// the goroutines running tests with a timeout exit before the test completes. This uses a parallel
+	// test since goroutines that cannot be traced are not reported in parallel tests.
+	result = internal.Run(func(t internal.T) {
+		is := newTest(t, nil, newOptions([]Option{DetectLeaks(), LeakGrace(50 * time.Millisecond)}), true, 0)
+		is.RunTimeout("timeout", time.Second, func(is Is) { go leakyWorker(stop) })
+		runCleanup(t.(*internal.Test))
+	})
+	if !result.Failed || !errors.Is(result.TestError, errGoroutineLeak) {
+		t.Fatal("Goroutine leaked by a test with a timeout was not detected")
+	}
+}
+
+func TestDetectLeaksPass(t *testing.T) {
+	result := runLeakTest(t, func(is Is) {
+		stop := make(chan struct{})
+		go leakyWorker(stop)
+		// the goroutine exits during the grace period.
+		time.AfterFunc(10*time.Millisecond, func() { close(stop) })
+	})
+	if result.Failed {
+		t.Fatalf("Goroutine that exited was reported as a leak: %s", result.FailMessage)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// goroutines started before the test are not leaks.
+	go leakyWorker(stop)
+	result = runLeakTest(t, func(is Is) {})
+	if result.Failed {
+		t.Fatalf("Goroutine started before the test was reported as a leak: %s", result.FailMessage)
+	}
+
+	result = runLeakTest(t, func(is Is) { go leakyWorker(stop) }, IgnoreGoroutines(pkgPath+".leakyWorker"))
+	if result.Failed {
+		t.Fatalf("Ignored goroutine was reported as a leak: %s", result.FailMessage)
+	}
+}
+
+// tracesGoroutines checks if the runtime reports the goroutine that created each goroutine.
+func tracesGoroutines() bool {
+	parent := make(chan int)
+	go func() {
+		g, _ := parseGoroutine(string(stacks(false)))
+		parent <- g.parent
+	}()
+	return <-parent != 0
+}
+
+func TestDetectLeaksParallel(t *testing.T) {
+	if !tracesGoroutines() {
+		t.Skip("the runtime does not report the parent of goroutines")
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// otherTest is a test running in parallel with the checked test. The goroutine that starts the
+	// worker exits, so the worker cannot be traced back to either test.
+	ready, started := make(chan struct{}), make(chan struct{})
+	otherTest := func() {
+		<-ready
+		go func() { go leakyWorker(stop); close(started) }()
+	}
+	go otherTest()
+
+	result := internal.Run(func(t internal.T) {
+		newTest(t, nil, newOptions([]Option{DetectLeaks(), LeakGrace(50 * time.Millisecond)}), true, 0)
+		close(ready)
+		<-started
+		time.Sleep(10 * time.Millisecond)
+		runCleanup(t.(*internal.Test))
+	})
+	if result.Failed {
+		t.Fatalf("Goroutine started by another test was reported as a leak: %s", result.FailMessage)
+	}
+}
+
+func TestStartedBy(t *testing.T) {
+	test, before := map[int]bool{1: true}, map[int]bool{1: true, 2: true}
+	byID := map[int]*goroutine{3: {id: 3, parent: 1}, 4: {id: 4, parent: 2}}
+
+	for _, c := range []struct {
+		parent   int
+		parallel bool
+		started  bool
+	}{
+		{parent: 3, parallel: true, started: true},
+		{parent: 4, started: false},
+		// the runtime did not report the parent.
+		{parent: 0, parallel: true, started: true},
+		// the parent has exited.
+		{parent: 5, started: true},
+		{parent: 5, parallel: true, started: false},
+	} {
+		g := goroutine{id: 10, parent: c.parent}
+		if g.startedBy(test, before, c.parallel, byID) != c.started {
+			t.Fatalf("Incorrect result for goroutine started by %d (parallel: %v)", c.parent, c.parallel)
+		}
+	}
+}
+
+func TestParseGoroutine(t *testing.T) {
+	g, ok := parseGoroutine("goroutine 7 [chan receive]:\nmain.worker(0x1, {0x2, 0x3})\n\t/main.go:12 +0x1d\n" +
+		"created by main.(*T).start in goroutine 1\n\t/main.go:20 +0x25")
+	if !ok {
+		t.Fatal("Failed to parse goroutine")
+	}
+
+	if g.id != 7 || g.parent != 1 || g.createdBy != "main.(*T).start at /main.go:20" {
+		t.Fatalf("Incorrect goroutine: %+v", g)
+	}
+
+	if len(g.funcs) != 2 || g.funcs[0] != "main.worker" || g.funcs[1] != "main.(*T).start" {
+		t.Fatalf("Incorrect functions: %q", g.funcs)
+	}
+}
@@ -0,0 +1,164 @@
+package is
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// InDelta checks if the difference between value and expected is at most delta.
+// value and expected must be integer or floating point numbers.
+func (is Is) InDelta(value, expected interface{}, delta float64, format string, args ...interface{}) {
+	is.t().Helper()
+
+	v, e, ok := is.toFloats(value, expected, format, args...)
+	if !ok {
+		return
+	}
+
+	if diff := math.Abs(v - e); !(diff <= delta) {
+		is.fail(errNotInDelta, fmt.Sprintf("Difference between %v and %v is %v, allowed delta is %v", value, expected, diff, delta), format, args...)
+	}
+}
+
+// InEpsilon checks if the relative error between value and expected is at most epsilon.
+// The relative error is |value-expected|/|expected|.
+// value and expected must be integer or floating point numbers.
+func (is Is) InEpsilon(value, expected interface{}, epsilon float64, format string, args ...interface{}) {
+	is.t().Helper()
+
+	v, e, ok := is.toFloats(value, expected, format, args...)
+	if !ok || v == e {
+		return
+	}
+
+	if e == 0 {
+		is.fail(errNotInEpsilon, fmt.Sprintf("Relative error between %v and %v is undefined because expected is 0", value, expected), format, args...)
+		return
+	}
+
+	if relErr := math.Abs(v-e) / math.Abs(e); !(relErr <= epsilon) {
+		is.fail(errNotInEpsilon, fmt.Sprintf("Relative error between %v and %v is %v, allowed epsilon is %v", value, expected, relErr, epsilon), format, args...)
+	}
+}
+
+// toFloats converts value and expected to float64.
+// If either value cannot be converted, the test fails.
+func (is Is) toFloats(value, expected interface{}, format string, args ...interface{}) (v, e float64, ok bool) {
+	v, okV := toFloat(value)
+	e, okE := toFloat(expected)
+	if !okV || !okE {
+		is.t().Helper()
+		is.fail(errUnsupportedType, fmt.Sprintf("Cannot compare %T and %T as numbers", value, expected), format, args...)
+		return 0, 0, false
+	}
+	return v, e, true
+}
+
+// toFloat converts the given integer or floating point number to a float64.
+func toFloat(i interface{}) (float64, bool) {
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// areFinite checks if x and y are floating point or complex values of the same type with no
+// infinite or NaN components. This is used to filter the values compared by [options.floatEqual].
+// NaN values are handled by [EquateNaN].
+func areFinite(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if !vx.IsValid() || !vy.IsValid() || vx.Type() != vy.Type() {
+		return false
+	}
+
+	switch vx.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return isFinite(vx.Float()) && isFinite(vy.Float())
+	case reflect.Complex64, reflect.Complex128:
+		cx, cy := vx.Complex(), vy.Complex()
+		return isFinite(real(cx)) && isFinite(imag(cx)) && isFinite(real(cy)) && isFinite(imag(cy))
+	default:
+		return false
+	}
+}
+
+func isFinite(f float64) bool { return !math.IsNaN(f) && !math.IsInf(f, 0) }
+
+// floatEqual compares two values accepted by [areFinite] using the tolerance set by [EquateApprox]
+// and [EquateULP].
+func (o *options) floatEqual(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	is32 := vx.Kind() == reflect.Float32 || vx.Kind() == reflect.Complex64
+
+	switch vx.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return o.floatPartEqual(vx.Float(), vy.Float(), is32)
+	default:
+		cx, cy := vx.Complex(), vy.Complex()
+		return o.floatPartEqual(real(cx), real(cy), is32) && o.floatPartEqual(imag(cx), imag(cy), is32)
+	}
+}
+
+// floatPartEqual compares two floating point numbers.
+// If is32 is set, x and y are float32 values and the ULP distance is calculated using float32.
+func (o *options) floatPartEqual(x, y float64, is32 bool) bool {
+	if x == y {
+		return true
+	}
+
+	if o.approx {
+		delta := math.Abs(x - y)
+		if delta <= o.approxMargin || delta <= o.approxFraction*math.Min(math.Abs(x), math.Abs(y)) {
+			return true
+		}
+	}
+
+	if o.ulp != 0 {
+		var dist uint64
+		if is32 {
+			dist = distance(orderedBits32(float32(x)), orderedBits32(float32(y)))
+		} else {
+			dist = distance(orderedBits64(x), orderedBits64(y))
+		}
+		return dist <= o.ulp
+	}
+
+	return false
+}
+
+// orderedBits64 maps f to an integer such that the order of the integers matches the order of the floats.
+// The distance between two mapped values is the number of representable values between them.
+// Both -0 and +0 are mapped to the same value.
+func orderedBits64(f float64) uint64 {
+	const sign = 1 << 63
+	b := math.Float64bits(f)
+	if b&sign != 0 {
+		return sign - b&^sign
+	}
+	return sign + b
+}
+
+// orderedBits32 is the float32 version of orderedBits64.
+func orderedBits32(f float32) uint64 {
+	const sign = 1 << 31
+	b := uint64(math.Float32bits(f))
+	if b&sign != 0 {
+		return sign - b&^sign
+	}
+	return sign + b
+}
+
+func distance(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
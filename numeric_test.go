@@ -0,0 +1,22 @@
+package is
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInDelta(t *testing.T) {
+	mustPass(t, func(is Is) { is.InDelta(1.05, 1, 0.1, "this should pass") })
+	mustPass(t, func(is Is) { is.InDelta(uint8(3), int64(5), 2, "this should pass") })
+	mustFail(t, errNotInDelta, func(is Is) { is.InDelta(1.5, 1, 0.1, "this should fail") })
+	mustFail(t, errNotInDelta, func(is Is) { is.InDelta(math.NaN(), 1, 0.1, "this should fail") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.InDelta("1", 1, 0.1, "this should fail") })
+}
+
+func TestInEpsilon(t *testing.T) {
+	mustPass(t, func(is Is) { is.InEpsilon(101, 100, 0.01, "this should pass") })
+	mustPass(t, func(is Is) { is.InEpsilon(0, 0, 0.01, "this should pass") })
+	mustFail(t, errNotInEpsilon, func(is Is) { is.InEpsilon(102, 100, 0.01, "this should fail") })
+	mustFail(t, errNotInEpsilon, func(is Is) { is.InEpsilon(0.001, 0, 0.01, "this should fail") })
+	mustFail(t, errUnsupportedType, func(is Is) { is.InEpsilon(1, nil, 0.01, "this should fail") })
+}
@@ -1,7 +1,9 @@
 package is
 
 import (
+	"math"
 	"reflect"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -49,6 +51,47 @@ func EquateEmpty(eq bool) Option {
 	return func(o *options) { o.equateEmpty = eq }
 }
 
+// EquateApprox sets if floating point values are considered equal when they are within the given
+// tolerance. Two values x and y are considered equal if |x-y| ≤ max(fraction*min(|x|, |y|), margin).
+// This applies to float and complex values nested anywhere in the compared values.
+// The real and imaginary parts of complex values are compared separately.
+// fraction and margin must be non-negative.
+func EquateApprox(fraction, margin float64) Option {
+	if fraction < 0 || margin < 0 || math.IsNaN(fraction) || math.IsNaN(margin) {
+		panic("is: EquateApprox: fraction and margin must be non-negative")
+	}
+
+	return func(o *options) {
+		o.approx = true
+		o.approxFraction = fraction
+		o.approxMargin = margin
+	}
+}
+
+// EquateULP sets if floating point values are considered equal when there are at most n representable
+// floating point values between them. This applies to the same values as [EquateApprox].
+// If both EquateULP and EquateApprox are used, values are equal if they satisfy either option.
+func EquateULP(n uint64) Option {
+	return func(o *options) { o.ulp = n }
+}
+
+// EquateApproxTime sets if two time.Time values are considered equal when they are within margin of
+// each other. A margin of zero disables this option.
+func EquateApproxTime(margin time.Duration) Option {
+	if margin < 0 {
+		panic("is: EquateApproxTime: margin must be non-negative")
+	}
+	return func(o *options) { o.approxTime = margin }
+}
+
+// EquateTimeInstant sets if two time.Time values are compared by the instant they represent,
+// ignoring their location and monotonic clock readings.
+// If this is disabled, time.Time values must have the same location and monotonic clock reading to be equal.
+// Default: true
+func EquateTimeInstant(eq bool) Option {
+	return func(o *options) { o.equateTimeInstant = eq }
+}
+
 // CmpOpt adds the given [cmp.Option]s to the list of options passed to [cmp.Diff].
 func CmpOpt(opts ...cmp.Option) Option {
 	return func(o *options) { o.userOpts = append(o.userOpts, opts...) }
@@ -64,13 +107,44 @@ type options struct {
 	equateErrors bool
 	equateEmpty  bool
 
+	approx         bool
+	approxFraction float64
+	approxMargin   float64
+	ulp            uint64
+
+	approxTime        time.Duration
+	equateTimeInstant bool
+
 	userOpts []cmp.Option
 
+	marshaler Marshaler
+	goldenDir string
+
+	detectLeaks       bool
+	leakGrace         time.Duration
+	ignoredGoroutines []string
+
+	timeout time.Duration
+
+	cmpFileMode bool
+
+	shuffle          bool
+	shuffleSeed      int64
+	declarationOrder bool
+
+	propertyRuns    int
+	propertySeed    int64
+	hasPropertySeed bool
+	generators      map[reflect.Type]reflect.Value
+
+	// nonFatal is set if failed checks should not stop the test.
+	nonFatal bool
+
 	cmpOpts []cmp.Option
 }
 
 func newOptions(opts []Option) *options {
-	o := &options{equateEmpty: true, equateNaN: true}
+	o := &options{equateEmpty: true, equateNaN: true, equateTimeInstant: true}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(o)
@@ -79,6 +153,44 @@ func newOptions(opts []Option) *options {
 	return o
 }
 
+// clone returns a copy of o.
+// Slices and maps are copied so that applying options to the copy does not modify o.
+func (o *options) clone() *options {
+	c := *o
+	c.cmpUnexported = append([]interface{}(nil), o.cmpUnexported...)
+	c.userOpts = append([]cmp.Option(nil), o.userOpts...)
+	c.ignoredGoroutines = append([]string(nil), o.ignoredGoroutines...)
+
+	if o.cmpUnexportedMap != nil {
+		c.cmpUnexportedMap = make(map[reflect.Type]struct{}, len(o.cmpUnexportedMap))
+		for t := range o.cmpUnexportedMap {
+			c.cmpUnexportedMap[t] = struct{}{}
+		}
+	}
+
+	if o.generators != nil {
+		c.generators = make(map[reflect.Type]reflect.Value, len(o.generators))
+		for t, g := range o.generators {
+			c.generators[t] = g
+		}
+	}
+	return &c
+}
+
+// with returns a copy of o with the given options applied.
+func (o *options) with(opts []Option) *options {
+	c := o.clone()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	// the options may change how values are compared.
+	c.cmpOpts = nil
+	return c
+}
+
 func (o *options) CmpOpts() []cmp.Option {
 	if o.cmpOpts == nil {
 		o.cmpOpts = append(o.cmpOpts, cmp.FilterPath(func(p cmp.Path) bool {
@@ -123,6 +235,14 @@ func (o *options) CmpOpts() []cmp.Option {
 			o.cmpOpts = append(o.cmpOpts, cmpopts.EquateNaNs())
 		}
 
+		if o.approx || o.ulp != 0 {
+			o.cmpOpts = append(o.cmpOpts, cmp.FilterValues(areFinite, cmp.Comparer(o.floatEqual)))
+		}
+
+		if o.approxTime != 0 || !o.equateTimeInstant {
+			o.cmpOpts = append(o.cmpOpts, cmp.Comparer(o.timeEqual))
+		}
+
 		o.cmpOpts = append(o.cmpOpts, o.userOpts...)
 	}
 
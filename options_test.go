@@ -116,3 +116,64 @@ func TestOptionEquateErrors(t *testing.T) {
 		t.Fatal("err1 and err2 were not considered to be equal with EquateErrors")
 	}
 }
+
+type approxTest struct {
+	F   float64
+	F32 float32
+	C   complex128
+	N   []namedFloat
+}
+
+type namedFloat float64
+
+func TestOptionEquateApprox(t *testing.T) {
+	v1 := approxTest{F: 1, F32: 1, C: complex(1, 1), N: []namedFloat{1}}
+	v2 := approxTest{F: 1.001, F32: 1.001, C: complex(1.001, 0.999), N: []namedFloat{1.001}}
+
+	if result := testEq(t, v1, v2); !result.Failed {
+		t.Fatal("Approximately equal values were considered equal without EquateApprox")
+	}
+
+	if result := testEq(t, v1, v2, EquateApprox(0.01, 0)); result.Failed {
+		t.Fatalf("Values were not considered equal with EquateApprox: %s", result.FailMessage)
+	}
+
+	if result := testEq(t, v1, v2, EquateApprox(0, 0.01)); result.Failed {
+		t.Fatalf("Values were not considered equal with EquateApprox: %s", result.FailMessage)
+	}
+
+	if result := testEq(t, v1, v2, EquateApprox(0.0001, 0)); !result.Failed {
+		t.Fatal("Values outside the tolerance were considered equal")
+	}
+
+	if result := testEq(t, math.NaN(), math.NaN(), EquateApprox(0.1, 0.1)); result.Failed {
+		t.Fatal("EquateApprox changed the behaviour of EquateNaN")
+	}
+}
+
+func TestOptionEquateULP(t *testing.T) {
+	f := 1.0
+	next := math.Nextafter(f, 2)
+	next2 := math.Nextafter(next, 2)
+
+	if result := testEq(t, f, next); !result.Failed {
+		t.Fatal("Adjacent floats were considered equal without EquateULP")
+	}
+
+	if result := testEq(t, f, next, EquateULP(1)); result.Failed {
+		t.Fatal("Adjacent floats were not considered equal with EquateULP(1)")
+	}
+
+	if result := testEq(t, f, next2, EquateULP(1)); !result.Failed {
+		t.Fatal("Floats 2 ULP apart were considered equal with EquateULP(1)")
+	}
+
+	f32 := float32(-1)
+	if result := testEq(t, f32, math.Nextafter32(f32, 0), EquateULP(1)); result.Failed {
+		t.Fatal("Adjacent float32s were not considered equal with EquateULP(1)")
+	}
+
+	if result := testEq(t, math.Copysign(0, -1), math.SmallestNonzeroFloat64, EquateULP(1)); result.Failed {
+		t.Fatal("-0 and the smallest positive float were not considered equal with EquateULP(1)")
+	}
+}
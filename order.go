@@ -0,0 +1,124 @@
+package is
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Shuffle runs the tests of suites in a random order chosen using the given seed.
+// The seed is logged so that the order can be replayed.
+//
+// If this option is not set, the IS_SHUFFLE environment variable is used instead. IS_SHUFFLE can be
+// set to a seed, or to "on" to use a random seed.
+func Shuffle(seed int64) Option {
+	return func(o *options) {
+		o.shuffle = true
+		o.shuffleSeed = seed
+	}
+}
+
+// DeclarationOrder runs the tests of suites in the order they are declared in the source code instead of
+// lexicographic order. Tests declared in different files are ordered by file name.
+// Tests whose position cannot be determined, such as methods promoted from embedded types, are run after
+// the other tests in lexicographic order.
+// [Shuffle] takes precedence over this option.
+func DeclarationOrder() Option {
+	return func(o *options) { o.declarationOrder = true }
+}
+
+// shuffleEnv returns the option set by the IS_SHUFFLE environment variable.
+// This returns nil if the environment variable is not set or is "off".
+func shuffleEnv() (Option, error) {
+	switch env := os.Getenv("IS_SHUFFLE"); env {
+	case "", "off":
+		return nil, nil
+	case "on":
+		return Shuffle(time.Now().UnixNano()), nil
+	default:
+		seed, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IS_SHUFFLE %q: %w", env, err)
+		}
+		return Shuffle(seed), nil
+	}
+}
+
+// sortTests sorts the tests of s according to the options of the suite.
+// suiteType is the type that the tests were found on.
+func (s *testSuite) sortTests(t internal.T, suiteType reflect.Type) {
+	switch {
+	case s.options.shuffle:
+		t.Logf("is.Suite: Shuffling tests in suite '%s' using seed %d. Set IS_SHUFFLE=%d to replay this order.", s.name, s.options.shuffleSeed, s.options.shuffleSeed)
+
+		r := rand.New(rand.NewSource(s.options.shuffleSeed))
+		r.Shuffle(len(s.tests), func(i, j int) { s.tests[i], s.tests[j] = s.tests[j], s.tests[i] })
+	case s.options.declarationOrder:
+		positions := make(map[string]declaration, len(s.tests))
+		for _, test := range s.tests {
+			positions[test.Name] = methodDeclaration(suiteType, test.Name)
+		}
+
+		sort.SliceStable(s.tests, func(i, j int) bool {
+			return positions[s.tests[i].Name].before(positions[s.tests[j].Name])
+		})
+	}
+}
+
+// declaration is the position a function is declared at.
+type declaration struct {
+	file string
+	line int
+}
+
+// methodDeclaration gets the position the method with the given name is declared at.
+// Methods of a pointer type that have a value receiver are wrappers generated by the compiler, so the
+// position of the method of the element type is used instead.
+func methodDeclaration(typ reflect.Type, name string) declaration {
+	if method, ok := typ.MethodByName(name); ok {
+		if d := declarationOf(method.Func); d.file != "" {
+			return d
+		}
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		if method, ok := typ.Elem().MethodByName(name); ok {
+			return declarationOf(method.Func)
+		}
+	}
+	return declaration{}
+}
+
+// declarationOf gets the position fn is declared at.
+// This returns the zero declaration if the position of fn is not known.
+func declarationOf(fn reflect.Value) declaration {
+	f := runtime.FuncForPC(fn.Pointer())
+	if f == nil {
+		return declaration{}
+	}
+
+	file, line := f.FileLine(f.Entry())
+	if file == "" || file == "<autogenerated>" {
+		return declaration{}
+	}
+	return declaration{file: file, line: line}
+}
+
+// before checks if d is declared before other.
+// Unknown declarations are after all known declarations.
+func (d declaration) before(other declaration) bool {
+	switch {
+	case d.file == "" || other.file == "":
+		return d.file != ""
+	case d.file != other.file:
+		return d.file < other.file
+	}
+	return d.line < other.line
+}
@@ -0,0 +1,95 @@
+package is
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type testOrder struct{}
+
+func (t *testOrder) TestC(Is) {}
+func (t *testOrder) TestA(Is) {}
+func (t *testOrder) TestB(Is) {}
+func (t testOrder) TestD(Is)  {}
+func (t *testOrder) TestE(Is) {}
+
+type testOrderEmbedded struct{ testOrder }
+
+func (t *testOrderEmbedded) TestY(Is) {}
+
+// suiteOrder returns the names of the tests of the suite in the order they are run.
+func suiteOrder(t *testing.T, suite interface{}, opts ...Option) string {
+	t.Helper()
+
+	var s *testSuite
+	result := internal.Run(func(t internal.T) { s = makeSuite(t, suite, false, opts) })
+	if result.Failed {
+		t.Fatalf("makeSuite failed: %s", result.FailMessage)
+	}
+
+	var names []string
+	for _, test := range s.tests {
+		names = append(names, test.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestDeclarationOrder(t *testing.T) {
+	if order := suiteOrder(t, &testOrder{}); order != "TestA,TestB,TestC,TestD,TestE" {
+		t.Fatalf("Tests were not sorted by name: %s", order)
+	}
+
+	if order := suiteOrder(t, &testOrder{}, DeclarationOrder()); order != "TestC,TestA,TestB,TestD,TestE" {
+		t.Fatalf("Tests were not sorted by declaration: %s", order)
+	}
+
+	// the positions of tests promoted from testOrder are not known.
+	if order := suiteOrder(t, &testOrderEmbedded{}, DeclarationOrder()); order != "TestY,TestA,TestB,TestC,TestD,TestE" {
+		t.Fatalf("Tests were not sorted by declaration: %s", order)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	sorted := suiteOrder(t, &testOrder{})
+
+	var shuffled bool
+	for seed := int64(0); seed < 10; seed++ {
+		order := suiteOrder(t, &testOrder{}, Shuffle(seed), DeclarationOrder())
+		if order != suiteOrder(t, &testOrder{}, Shuffle(seed)) {
+			t.Fatalf("Shuffle with seed %d is not deterministic", seed)
+		}
+		shuffled = shuffled || order != sorted
+	}
+
+	if !shuffled {
+		t.Fatal("Shuffle did not change the order of tests")
+	}
+
+	seed3, seed4 := suiteOrder(t, &testOrder{}, Shuffle(3)), suiteOrder(t, &testOrder{}, Shuffle(4))
+
+	os.Setenv("IS_SHUFFLE", "3")
+	defer os.Unsetenv("IS_SHUFFLE")
+
+	if suiteOrder(t, &testOrder{}) != seed3 {
+		t.Fatal("IS_SHUFFLE was not used as the seed")
+	}
+
+	if suiteOrder(t, &testOrder{}, Shuffle(4)) != seed4 {
+		t.Fatal("Shuffle did not take precedence over IS_SHUFFLE")
+	}
+
+	os.Setenv("IS_SHUFFLE", "off")
+	if order := suiteOrder(t, &testOrder{}); order != sorted {
+		t.Fatalf("IS_SHUFFLE=off shuffled tests: %s", order)
+	}
+
+	os.Setenv("IS_SHUFFLE", "invalid")
+	result := internal.Run(func(t internal.T) { makeSuite(t, &testOrder{}, false, nil) })
+	if !result.Failed || !errors.Is(result.TestError, errShuffleSeed) {
+		t.Fatal("allowed invalid IS_SHUFFLE")
+	}
+}
@@ -0,0 +1,181 @@
+package is
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Eventually checks if fn returns true within the given timeout.
+// fn is called every interval until it returns true or the timeout expires.
+// If fn does not return true before the timeout the test fails.
+func (is Is) Eventually(fn func() bool, timeout, interval time.Duration, format string, args ...interface{}) {
+	if !poll(timeout, interval, true, fn) {
+		is.t().Helper()
+		is.fail(errNotEventually, fmt.Sprintf("Condition was not satisfied within %s", timeout), format, args...)
+	}
+}
+
+// Consistently checks if fn returns true every time it is called until the timeout expires.
+// fn is called every interval. If fn returns false the test fails.
+func (is Is) Consistently(fn func() bool, timeout, interval time.Duration, format string, args ...interface{}) {
+	if !poll(timeout, interval, false, fn) {
+		is.t().Helper()
+		is.fail(errNotConsistently, fmt.Sprintf("Condition was not satisfied for %s", timeout), format, args...)
+	}
+}
+
+// EventuallyWith is like [Is.Eventually] but fn may use the given [Is] to run checks.
+// A failed check inside fn stops the current call to fn and is treated as the condition not being
+// satisfied yet. If the timeout expires the test fails with the last failure reported by fn.
+// Subtests started inside fn are subtests of the test and their failures are not recorded.
+func (is Is) EventuallyWith(fn func(Is), timeout, interval time.Duration, format string, args ...interface{}) {
+	s := is.state()
+	var last string
+	ok := poll(timeout, interval, true, func() (ok bool) {
+		ok, last = runRecorded(s, fn)
+		return ok
+	})
+
+	if !ok {
+		s.t.Helper()
+		is.fail(errNotEventually, fmt.Sprintf("Condition was not satisfied within %s. Last failure:\n%s", timeout, last), format, args...)
+	}
+}
+
+// ConsistentlyWith is like [Is.Consistently] but fn may use the given [Is] to run checks.
+// A failed check inside fn causes the test to fail with the failure reported by fn.
+// Subtests started inside fn are subtests of the test and their failures are not recorded.
+func (is Is) ConsistentlyWith(fn func(Is), timeout, interval time.Duration, format string, args ...interface{}) {
+	s := is.state()
+	var last string
+	ok := poll(timeout, interval, false, func() (ok bool) {
+		ok, last = runRecorded(s, fn)
+		return ok
+	})
+
+	if !ok {
+		s.t.Helper()
+		is.fail(errNotConsistently, fmt.Sprintf("Condition was not satisfied for %s. Failure:\n%s", timeout, last), format, args...)
+	}
+}
+
+// poll calls check every interval until check returns stopOn or the timeout expires.
+// This returns the value returned by the last call to check.
+// check is always called at least once.
+func poll(timeout, interval time.Duration, stopOn bool, check func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if ok := check(); ok == stopOn || !time.Now().Before(deadline) {
+			return ok
+		}
+
+		if remaining := time.Until(deadline); remaining < interval {
+			time.Sleep(remaining)
+		} else {
+			time.Sleep(interval)
+		}
+	}
+}
+
+// runRecorded calls fn with an [Is] that records failures instead of failing the test.
+// This reports if fn passed and the failures reported by fn.
+func runRecorded(s *state, fn func(Is)) (ok bool, failures string) {
+	opts := s.options.clone()
+	opts.nonFatal = false
+
+	t := &recordT{T: s.t}
+	t.run(func() { fn(newState(t, opts, s.test).Is) })
+
+	return !t.failed, t.String()
+}
+
+// unwrapT gets the test used by t.
+// Checks inside EventuallyWith, ConsistentlyWith and the Setup and Teardown methods of suites are recorded
+// using recordT.
+func unwrapT(t internal.T) internal.T {
+	for r, ok := t.(*recordT); ok; r, ok = t.(*recordT) {
+		t = r.T
+	}
+	return t
+}
+
+// recordT is an [internal.T] that records failures instead of failing the underlying test.
+// Calls to FailNow and Fatalf stop the function passed to [recordT.run].
+type recordT struct {
+	internal.T
+
+	mu       sync.Mutex
+	failed   bool
+	failures []string
+}
+
+// recordFailNow is the value recordT panics with to stop the current function.
+type recordFailNow struct{}
+
+// run calls fn and recovers from calls to [recordT.FailNow].
+func (t *recordT) run(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(recordFailNow); !ok {
+				panic(r)
+			}
+		}
+	}()
+
+	fn()
+}
+
+// runRecover is like [recordT.run] but also records panics in fn as failures.
+func (t *recordT) runRecover(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.record(fmt.Sprintf("Function panicked: %v\n%s", r, debug.Stack()))
+		}
+	}()
+
+	t.run(fn)
+}
+
+func (t *recordT) Error(v ...interface{}) { t.record(fmt.Sprint(v...)) }
+
+func (t *recordT) Errorf(format string, args ...interface{}) { t.record(fmt.Sprintf(format, args...)) }
+
+func (t *recordT) Fatalf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+	t.FailNow()
+}
+
+func (t *recordT) FailNow() {
+	t.mu.Lock()
+	t.failed = true
+	t.mu.Unlock()
+
+	panic(recordFailNow{})
+}
+
+func (t *recordT) record(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failed = true
+	// when the user does not provide a message, Errorf is called with an empty format string.
+	if msg != "" {
+		t.failures = append(t.failures, msg)
+	}
+}
+
+// String returns all recorded failures.
+func (t *recordT) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.failed && len(t.failures) == 0 {
+		return "check failed"
+	}
+	return strings.Join(t.failures, "\n")
+}
@@ -0,0 +1,85 @@
+package is
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestEventually(t *testing.T) {
+	mustFail(t, errNotEventually, func(is Is) {
+		is.Eventually(func() bool { return false }, 20*time.Millisecond, time.Millisecond, "this should fail")
+	})
+
+	mustPass(t, func(is Is) {
+		var calls int32
+		is.Eventually(func() bool { return atomic.AddInt32(&calls, 1) == 3 }, time.Second, time.Millisecond, "this should pass")
+	})
+
+	mustFail(t, errNotEventually, func(is Is) {
+		is.EventuallyWith(func(is Is) { is.Equal(1, 2, "not equal") }, 20*time.Millisecond, time.Millisecond, "this should fail")
+	})
+
+	mustPass(t, func(is Is) {
+		var calls int
+		is.EventuallyWith(func(is Is) {
+			calls++
+			is(calls >= 3, "not yet")
+		}, time.Second, time.Millisecond, "this should pass")
+	})
+}
+
+func TestConsistently(t *testing.T) {
+	mustPass(t, func(is Is) {
+		is.Consistently(func() bool { return true }, 20*time.Millisecond, time.Millisecond, "this should pass")
+	})
+
+	mustFail(t, errNotConsistently, func(is Is) {
+		var calls int
+		is.Consistently(func() bool { calls++; return calls < 3 }, time.Second, time.Millisecond, "this should fail")
+	})
+
+	mustPass(t, func(is Is) {
+		is.ConsistentlyWith(func(is Is) { is.Equal(1, 1, "equal") }, 20*time.Millisecond, time.Millisecond, "this should pass")
+	})
+
+	mustFail(t, errNotConsistently, func(is Is) {
+		is.ConsistentlyWith(func(is Is) { is(false, "") }, time.Second, time.Millisecond, "this should fail")
+	})
+}
+
+func TestEventuallyLastFailure(t *testing.T) {
+	result := internal.Run(func(t internal.T) {
+		newIs(t, &options{}).EventuallyWith(func(is Is) { is.Equal("a", "b", "values differ") }, 0, time.Millisecond, "")
+	})
+
+	message := strings.Join(result.FailMessage, "\n")
+	if !strings.Contains(message, "values differ") || !strings.Contains(message, "Values are not equal") {
+		t.Fatalf("Failure message does not contain the last failure: %s", message)
+	}
+}
+
+func TestEventuallyT(t *testing.T) {
+	is := New(t)
+	is.EventuallyWith(func(is Is) { is(is.T() == t, "T did not return the test's *testing.T") }, time.Second, time.Millisecond, "")
+	is.ConsistentlyWith(func(is Is) { is(is.T() == t, "T did not return the test's *testing.T") }, 0, time.Millisecond, "")
+}
+
+func TestEventuallyRun(t *testing.T) {
+	result := internal.Run(func(t internal.T) {
+		is := newIs(t, &options{})
+		is.EventuallyWith(func(is Is) { is.Run("eventually", func(Is) {}) }, time.Second, time.Millisecond, "")
+		is.ConsistentlyWith(func(is Is) { is.Run("consistently", func(Is) {}) }, 0, time.Millisecond, "")
+	})
+
+	var names []string
+	for _, test := range result.RunTests {
+		names = append(names, test.Name)
+	}
+	if got := strings.Join(names, ","); result.Failed || got != "eventually,consistently" {
+		t.Fatalf("Subtests were not run inside EventuallyWith and ConsistentlyWith: %s %s", got, result.FailMessage)
+	}
+}
@@ -0,0 +1,623 @@
+package is
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultPropertyRuns is the number of random cases checked by [Is.Property] if [PropertyRuns] is not set.
+	defaultPropertyRuns = 100
+	// propertyMaxSize is the maximum size of the values generated by [Is.Property].
+	// The size limits the length of strings, slices and maps and the magnitude of numbers.
+	propertyMaxSize = 100
+	// propertyMaxShrinks is the maximum number of times a failing case is checked while shrinking it.
+	propertyMaxShrinks = 1000
+)
+
+var randType = reflect.TypeOf((*rand.Rand)(nil))
+
+// PropertyRuns sets the number of random cases checked by [Is.Property].
+// Default: 100
+func PropertyRuns(n int) Option {
+	if n < 1 {
+		panic("is: PropertyRuns: n must be positive")
+	}
+	return func(o *options) { o.propertyRuns = n }
+}
+
+// PropertySeed sets the seed used to generate the arguments passed to [Is.Property].
+// By default the seed is read from the IS_PROPERTY_SEED environment variable, or chosen at random if
+// the environment variable is not set.
+func PropertySeed(seed int64) Option {
+	return func(o *options) {
+		o.propertySeed = seed
+		o.hasPropertySeed = true
+	}
+}
+
+// PropertyGenerator registers a generator for the values of type T used by [Is.Property].
+// gen must be a func(*rand.Rand) T. Values created by generators are not shrunk.
+func PropertyGenerator(gen interface{}) Option {
+	v := reflect.ValueOf(gen)
+	if v.Kind() != reflect.Func || v.Type().NumIn() != 1 || v.Type().In(0) != randType || v.Type().NumOut() != 1 {
+		panic(fmt.Sprintf("is: PropertyGenerator: gen must be a func(*rand.Rand) T not %T", gen))
+	}
+
+	return func(o *options) {
+		if o.generators == nil {
+			o.generators = make(map[reflect.Type]reflect.Value)
+		}
+		o.generators[v.Type().Out(0)] = v
+	}
+}
+
+// Property checks that fn holds for randomly generated arguments.
+// fn must either return a bool or take [Is] as its first argument. The remaining arguments of fn are
+// generated by reflecting over their types. Booleans, numbers, strings, slices, arrays, maps, pointers
+// and the exported fields of structs are supported. Generators for other types can be registered
+// using [PropertyGenerator].
+//
+//	is.Property(func(a, b int) bool { return a+b == b+a })
+//	is.Property(func(is is.Is, s []string) { is.Len(s, len(s), "") })
+//
+// fn fails if it returns false, if a check using the given [Is] fails or if it panics.
+// When fn fails, the arguments are shrunk to a smaller case that still fails, and the test fails
+// with this case and the seed used to generate it. Set the IS_PROPERTY_SEED environment variable
+// to the seed to replay the same cases.
+//
+// The options given to Property are applied on top of the options of this test.
+func (is Is) Property(fn interface{}, opts ...Option) {
+	s := is.state()
+	s.t.Helper()
+
+	p, err := newProperty(s, fn, s.options.with(opts))
+	if err != "" {
+		is.fail(errPropertyFunc, err, "is.Property")
+		return
+	}
+
+	seed := p.options.propertySeed
+	if !p.options.hasPropertySeed {
+		seed = time.Now().UnixNano()
+		if env := os.Getenv("IS_PROPERTY_SEED"); env != "" {
+			var parseErr error
+			if seed, parseErr = strconv.ParseInt(env, 10, 64); parseErr != nil {
+				is.fail(errPropertySeed, fmt.Sprintf("Invalid IS_PROPERTY_SEED %q: %s", env, parseErr), "is.Property")
+				return
+			}
+		}
+	}
+
+	runs := p.options.propertyRuns
+	if runs == 0 {
+		runs = defaultPropertyRuns
+	}
+
+	p.rand = rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		size := 1 + run*propertyMaxSize/runs
+
+		args := make([]reflect.Value, len(p.params))
+		for i, param := range p.params {
+			args[i] = p.generate(param, size)
+		}
+
+		ok, failure := p.check(args)
+		if ok {
+			continue
+		}
+
+		args, failure, shrinks := p.shrinkArgs(args, failure)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Property failed after %d run(s) and %d shrink(s).\n", run+1, shrinks)
+		fmt.Fprintf(&b, "Seed: %d (set IS_PROPERTY_SEED=%d to replay)\nCounterexample:", seed, seed)
+		for i, arg := range args {
+			fmt.Fprintf(&b, "\n\t#%d: %#v", i, arg.Interface())
+		}
+		fmt.Fprintf(&b, "\nFailure:\n\t%s", strings.ReplaceAll(failure, "\n", "\n\t"))
+
+		is.fail(errPropertyFailed, b.String(), "is.Property")
+		return
+	}
+}
+
+// property is a property being checked by [Is.Property].
+type property struct {
+	state   *state
+	options *options
+	rand    *rand.Rand
+
+	fn      reflect.Value
+	takesIs bool
+	params  []reflect.Type
+}
+
+// newProperty validates fn and creates a property for it.
+// If fn is invalid, this returns a message describing why.
+func newProperty(s *state, fn interface{}, opts *options) (*property, string) {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, fmt.Sprintf("fn must be a function not %T", fn)
+	}
+
+	fnType := fnValue.Type()
+	if fnType.IsVariadic() {
+		return nil, fmt.Sprintf("fn must not be variadic: %s", fnType)
+	}
+
+	// a failed check must not stop the test. See [runRecorded].
+	opts.nonFatal = false
+	p := &property{state: s, options: opts, fn: fnValue}
+
+	returnsBool := fnType.NumOut() == 1 && fnType.Out(0).Kind() == reflect.Bool
+	if fnType.NumOut() > 1 || (fnType.NumOut() == 1 && !returnsBool) {
+		return nil, fmt.Sprintf("fn must return a bool or nothing: %s", fnType)
+	}
+
+	for i := 0; i < fnType.NumIn(); i++ {
+		if i == 0 && fnType.In(0) == reflect.TypeOf(Is(nil)) {
+			p.takesIs = true
+			continue
+		}
+
+		param := fnType.In(i)
+		if !p.canGenerate(param, map[reflect.Type]bool{}) {
+			return nil, fmt.Sprintf("Cannot generate values of type %s. Use is.PropertyGenerator to add a generator", param)
+		}
+		p.params = append(p.params, param)
+	}
+
+	if !p.takesIs && !returnsBool {
+		return nil, fmt.Sprintf("fn must return a bool or take is.Is as its first argument: %s", fnType)
+	}
+
+	return p, ""
+}
+
+// check calls fn with the given arguments and reports if it succeeded.
+// If fn failed, failure describes why.
+func (p *property) check(args []reflect.Value) (ok bool, failure string) {
+	t := &recordT{T: p.state.t}
+
+	in := make([]reflect.Value, 0, len(args)+1)
+	if p.takesIs {
+		in = append(in, reflect.ValueOf(newState(t, p.options, p.state.test).Is))
+	}
+	// copy the arguments so that fn cannot modify the values being shrunk.
+	for _, arg := range args {
+		in = append(in, p.copy(arg))
+	}
+
+	var out []reflect.Value
+	t.runRecover(func() { out = p.fn.Call(in) })
+
+	if len(out) == 1 && !out[0].Bool() {
+		t.record("Function returned false")
+	}
+
+	return !t.failed, t.String()
+}
+
+// shrinkArgs tries to find smaller arguments that still cause the property to fail.
+// This returns the smallest arguments found, the failure caused by them and the number of times the
+// arguments were shrunk.
+func (p *property) shrinkArgs(args []reflect.Value, failure string) ([]reflect.Value, string, int) {
+	var shrinks, attempts int
+
+	for shrunk := true; shrunk && attempts < propertyMaxShrinks; {
+		shrunk = false
+
+		for i := range args {
+			p.shrink(args[i], func(candidate reflect.Value) bool {
+				if attempts++; attempts > propertyMaxShrinks {
+					return true
+				}
+
+				next := append([]reflect.Value(nil), args...)
+				next[i] = candidate
+				if ok, f := p.check(next); !ok {
+					args, failure, shrunk = next, f, true
+					shrinks++
+					return true
+				}
+				return false
+			})
+
+			if shrunk {
+				break
+			}
+		}
+	}
+
+	return args, failure, shrinks
+}
+
+// canGenerate checks if values of type t can be generated.
+// seen contains the types that are being checked, which allows recursive types.
+func (p *property) canGenerate(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if _, ok := p.options.generators[t]; ok || seen[t] {
+		return true
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		return p.canGenerate(t.Elem(), seen)
+	case reflect.Map:
+		return p.canGenerate(t.Key(), seen) && p.canGenerate(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.PkgPath == "" && !p.canGenerate(field.Type, seen) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// generate generates a random value of type t.
+// size limits the length of strings, slices and maps, and the magnitude of numbers.
+// Values nested in slices, maps and pointers are generated using a smaller size.
+func (p *property) generate(t reflect.Type, size int) reflect.Value {
+	if gen, ok := p.options.generators[t]; ok {
+		return gen.Call([]reflect.Value{reflect.ValueOf(p.rand)})[0]
+	}
+
+	r := p.rand
+	v := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if r.Intn(10) == 0 {
+			// use an edge case.
+			bits := t.Bits()
+			v.SetInt([]int64{0, 1, -1, -1 << (bits - 1), 1<<(bits-1) - 1}[r.Intn(5)])
+		} else {
+			v.SetInt(r.Int63n(int64(2*size+1)) - int64(size))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if r.Intn(10) == 0 {
+			v.SetUint([]uint64{0, 1, math.MaxUint64 >> (64 - t.Bits())}[r.Intn(3)])
+		} else {
+			v.SetUint(uint64(r.Int63n(int64(size + 1))))
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(p.float(t.Bits(), size))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(p.float(t.Bits()/2, size), p.float(t.Bits()/2, size)))
+	case reflect.String:
+		runes := make([]rune, r.Intn(size+1))
+		for i := range runes {
+			if r.Intn(10) == 0 {
+				runes[i] = rune(0xa0 + r.Intn(0x3000))
+			} else {
+				runes[i] = rune(' ' + r.Intn('~'-' '+1))
+			}
+		}
+		v.SetString(string(runes))
+	case reflect.Slice:
+		n := r.Intn(size + 1)
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := 0; i < n; i++ {
+			v.Index(i).Set(p.generate(t.Elem(), size/2))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			v.Index(i).Set(p.generate(t.Elem(), size/2))
+		}
+	case reflect.Map:
+		n := r.Intn(size + 1)
+		v.Set(reflect.MakeMapWithSize(t, n))
+		for i := 0; i < n; i++ {
+			v.SetMapIndex(p.generate(t.Key(), size/2), p.generate(t.Elem(), size/2))
+		}
+	case reflect.Ptr:
+		// the size decreases every time a pointer is followed. This stops recursive types from being infinitely large.
+		if size != 0 && r.Intn(10) != 0 {
+			v.Set(reflect.New(t.Elem()))
+			v.Elem().Set(p.generate(t.Elem(), size/2))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				v.Field(i).Set(p.generate(t.Field(i).Type, size))
+			}
+		}
+	}
+
+	return v
+}
+
+// float generates a random finite float with the given number of bits.
+func (p *property) float(bits, size int) float64 {
+	if p.rand.Intn(10) == 0 {
+		max, min := math.MaxFloat64, math.SmallestNonzeroFloat64
+		if bits == 32 {
+			max, min = math.MaxFloat32, math.SmallestNonzeroFloat32
+		}
+		return []float64{0, 1, -1, max, -max, min}[p.rand.Intn(6)]
+	}
+	return (p.rand.Float64()*2 - 1) * float64(size)
+}
+
+// copy returns a deep copy of v. Values created by generators are not copied.
+func (p *property) copy(v reflect.Value) reflect.Value {
+	if _, ok := p.options.generators[v.Type()]; ok {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(p.copy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(p.copy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, k := range v.MapKeys() {
+			c.SetMapIndex(k, p.copy(v.MapIndex(k)))
+		}
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(p.copy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(p.copy(v.Field(i)))
+			}
+		}
+		return c
+	}
+
+	return v
+}
+
+// shrink calls yield with values that are smaller than v, starting with the smallest values.
+// This stops and returns true once yield returns true.
+// Values created by generators are not shrunk.
+func (p *property) shrink(v reflect.Value, yield func(reflect.Value) bool) bool {
+	t := v.Type()
+	if _, ok := p.options.generators[t]; ok {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return yield(reflect.Zero(t))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// try values between 0 and v, getting closer to v each time.
+		for x, d := v.Int(), v.Int(); d != 0; d /= 2 {
+			c := reflect.New(t).Elem()
+			c.SetInt(x - d)
+			if yield(c) {
+				return true
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		for x, d := v.Uint(), v.Uint(); d != 0; d /= 2 {
+			c := reflect.New(t).Elem()
+			c.SetUint(x - d)
+			if yield(c) {
+				return true
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		x := v.Float()
+		if x == 0 {
+			return false
+		}
+
+		candidates := []float64{0}
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			if trunc := math.Trunc(x); trunc != x {
+				candidates = append(candidates, trunc)
+			}
+			for i, d := 0, x/2; i < 8; i, d = i+1, d/2 {
+				candidates = append(candidates, x-d)
+			}
+		}
+
+		for _, f := range candidates {
+			c := reflect.New(t).Elem()
+			c.SetFloat(f)
+			if c.Float() != x && yield(c) {
+				return true
+			}
+		}
+	case reflect.Complex64, reflect.Complex128:
+		x := v.Complex()
+		for _, f := range []complex128{0, complex(real(x), 0)} {
+			c := reflect.New(t).Elem()
+			c.SetComplex(f)
+			if c.Complex() != x && yield(c) {
+				return true
+			}
+		}
+	case reflect.String:
+		runes := []rune(v.String())
+		return shrinkLen(len(runes), func(i, j int) bool {
+			c := reflect.New(t).Elem()
+			c.SetString(string(runes[:i]) + string(runes[j:]))
+			return yield(c)
+		})
+	case reflect.Slice:
+		n := v.Len()
+		if shrinkLen(n, func(i, j int) bool {
+			c := reflect.MakeSlice(t, 0, n-(j-i))
+			c = reflect.AppendSlice(c, v.Slice(0, i))
+			return yield(reflect.AppendSlice(c, v.Slice(j, n)))
+		}) {
+			return true
+		}
+
+		for i := 0; i < n; i++ {
+			i := i
+			if p.shrink(v.Index(i), func(e reflect.Value) bool {
+				c := reflect.MakeSlice(t, n, n)
+				reflect.Copy(c, v)
+				c.Index(i).Set(e)
+				return yield(c)
+			}) {
+				return true
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			i := i
+			if p.shrink(v.Index(i), func(e reflect.Value) bool {
+				c := reflect.New(t).Elem()
+				c.Set(v)
+				c.Index(i).Set(e)
+				return yield(c)
+			}) {
+				return true
+			}
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			return false
+		}
+
+		if yield(reflect.MakeMap(t)) {
+			return true
+		}
+
+		// sort the keys so that shrinking is deterministic.
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+		copyMap := func() reflect.Value {
+			c := reflect.MakeMapWithSize(t, v.Len())
+			for _, k := range keys {
+				c.SetMapIndex(k, v.MapIndex(k))
+			}
+			return c
+		}
+
+		for _, k := range keys {
+			c := copyMap()
+			c.SetMapIndex(k, reflect.Value{})
+			if yield(c) {
+				return true
+			}
+		}
+
+		for _, k := range keys {
+			k := k
+			if p.shrink(k, func(e reflect.Value) bool {
+				c := copyMap()
+				c.SetMapIndex(k, reflect.Value{})
+				c.SetMapIndex(e, v.MapIndex(k))
+				return yield(c)
+			}) {
+				return true
+			}
+		}
+
+		for _, k := range keys {
+			k := k
+			if p.shrink(v.MapIndex(k), func(e reflect.Value) bool {
+				c := copyMap()
+				c.SetMapIndex(k, e)
+				return yield(c)
+			}) {
+				return true
+			}
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return false
+		}
+
+		if yield(reflect.Zero(t)) {
+			return true
+		}
+
+		return p.shrink(v.Elem(), func(e reflect.Value) bool {
+			c := reflect.New(t.Elem())
+			c.Elem().Set(e)
+			return yield(c)
+		})
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			i := i
+			if !v.Field(i).CanInterface() {
+				continue
+			}
+
+			if p.shrink(v.Field(i), func(e reflect.Value) bool {
+				c := reflect.New(t).Elem()
+				c.Set(v)
+				c.Field(i).Set(e)
+				return yield(c)
+			}) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// shrinkLen calls remove with ranges [i, j) to remove from a sequence of length n.
+// The first range removes the whole sequence and later ranges get smaller.
+// This stops and returns true once remove returns true.
+func shrinkLen(n int, remove func(i, j int) bool) bool {
+	if n == 0 {
+		return false
+	}
+
+	if remove(0, n) {
+		return true
+	}
+
+	for k := n / 2; k > 0; k /= 2 {
+		for i := 0; i+k <= n; i += k {
+			if remove(i, i+k) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package is
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type propertyNode struct {
+	Value int
+	Next  *propertyNode
+}
+
+// runProperty runs is.Property using the default options.
+func runProperty(fn interface{}, opts ...Option) *internal.Test {
+	return internal.Run(func(t internal.T) { newIs(t, newOptions(nil)).Property(fn, opts...) })
+}
+
+// mustFailProperty checks that the property fails with the given counterexample.
+func mustFailProperty(t *testing.T, counterexample string, fn interface{}, opts ...Option) {
+	t.Helper()
+
+	result := runProperty(fn, append([]Option{PropertySeed(1)}, opts...)...)
+	if !result.Failed || !errors.Is(result.TestError, errPropertyFailed) {
+		t.Fatalf("Property did not fail: %s", result.TestError)
+	}
+
+	message := strings.Join(result.FailMessage, "\n")
+	if !strings.Contains(message, "\t#0: "+counterexample+"\n") {
+		t.Fatalf("Property was not shrunk to %s:\n%s", counterexample, message)
+	}
+}
+
+func TestProperty(t *testing.T) {
+	mustPass(t, func(is Is) { is.Property(func(a, b int) bool { return a+b == b+a }) })
+	mustPass(t, func(is Is) {
+		is.Property(func(is Is, s []string, m map[string]int, n *propertyNode, a [2]float64, c complex128) {
+			is.Len(s, len(s), "")
+		})
+	})
+
+	var runs int
+	mustPass(t, func(is Is) { is.Property(func(bool) bool { runs++; return true }, PropertyRuns(10)) })
+	if runs != 10 {
+		t.Fatalf("Property was checked %d times not 10", runs)
+	}
+
+	New(t).Property(func(is Is, x uint8) { is(int(x) >= 0, "") })
+}
+
+func TestPropertyShrink(t *testing.T) {
+	mustFailProperty(t, "10", func(x int) bool { return x < 10 })
+	mustFailProperty(t, "0x5", func(x uint) bool { return x < 5 })
+	mustFailProperty(t, "[]int{0, 0, 0}", func(s []int) bool { return len(s) < 3 })
+	mustFailProperty(t, `"a"`, func(s string) bool { return !strings.Contains(s, "a") })
+	mustFailProperty(t, `map[string]int{"":2}`, func(m map[string]int) bool {
+		for _, v := range m {
+			if v > 1 {
+				return false
+			}
+		}
+		return true
+	})
+	mustFailProperty(t, `is.propertyNode{Value:0, Next:(*is.propertyNode)(nil)}`, func(is Is, n propertyNode) {
+		is(n.Next != nil, "")
+	})
+	mustFailProperty(t, "-3", func(is Is, x int) {
+		if x <= -3 {
+			panic("negative")
+		}
+	})
+
+	// fn must not be able to modify the counterexample.
+	mustFailProperty(t, "[]int{0}", func(s []int) bool {
+		if len(s) == 0 {
+			return true
+		}
+		s[0] = 1
+		return false
+	})
+}
+
+func TestPropertySeed(t *testing.T) {
+	fn := func(s []int) bool { return len(s) < 20 }
+	first := strings.Join(runProperty(fn, PropertySeed(42)).FailMessage, "\n")
+	if !strings.Contains(first, "IS_PROPERTY_SEED=42") {
+		t.Fatalf("Seed was not reported:\n%s", first)
+	}
+
+	t.Setenv("IS_PROPERTY_SEED", "42")
+
+	if replay := strings.Join(runProperty(fn).FailMessage, "\n"); replay != first {
+		t.Fatalf("Replaying the seed gave a different result:\n%s\n%s", first, replay)
+	}
+
+	t.Setenv("IS_PROPERTY_SEED", "invalid")
+	mustFail(t, errPropertySeed, func(is Is) { is.Property(fn) })
+}
+
+type propertyUnexported struct{ v int }
+
+func TestPropertyOptions(t *testing.T) {
+	result := internal.Run(func(t internal.T) {
+		is := newIs(t, newOptions([]Option{CmpUnexported(exportTest{})}))
+		is.Property(func(int) bool { return true }, CmpUnexported(propertyUnexported{}))
+		// the options passed to Property must not be applied to the test.
+		is.Equal(propertyUnexported{1}, propertyUnexported{2}, "")
+	})
+	if result.Failed {
+		t.Fatalf("Options passed to Property were applied to the test: %s", result.FailMessage)
+	}
+}
+
+func TestPropertyGenerator(t *testing.T) {
+	mustPass(t, func(is Is) {
+		is.Property(func(c chan int, s struct{ C chan int }) bool { return c != nil && s.C != nil },
+			PropertyGenerator(func(r *rand.Rand) chan int { return make(chan int) }))
+	})
+
+	mustFailProperty(t, "7", func(x int) bool { return x != 7 },
+		PropertyGenerator(func(r *rand.Rand) int { return 7 }))
+
+	for _, gen := range []interface{}{nil, 1, func() int { return 0 }, func(*rand.Rand) {}} {
+		gen := gen
+		mustPass(t, func(is Is) { is.Panic(func() { PropertyGenerator(gen) }, "") })
+	}
+}
+
+func TestPropertyInvalid(t *testing.T) {
+	for _, fn := range []interface{}{
+		nil,
+		1,
+		(func(int) bool)(nil),
+		func(int) {},
+		func(int) int { return 0 },
+		func(int) (bool, bool) { return true, true },
+		func(...int) bool { return true },
+		func(chan int) bool { return true },
+		func(struct{ F func() }) bool { return true },
+	} {
+		mustFail(t, errPropertyFunc, func(is Is) { is.Property(fn) })
+	}
+
+	mustFail(t, errPropertyFunc, func(is Is) { is.Property(func(Is, interface{}) {}) })
+	mustPass(t, func(is Is) { is.Property(func(Is, struct{ f func() }) {}) })
+}
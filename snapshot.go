@@ -0,0 +1,198 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InlineSnapshot is an expected value that is stored in the source code of a test.
+// See [Is.Snapshot].
+type InlineSnapshot struct{ expected string }
+
+// Inline creates an [InlineSnapshot] with the given expected value.
+// expected must be a string literal so that it can be updated by [Is.Snapshot].
+func Inline(expected string) InlineSnapshot {
+	return InlineSnapshot{expected: expected}
+}
+
+// Snapshot checks if the serialized value is equal to the expected value stored in snapshot.
+// The value is serialized using the [Marshaler] set by [GoldenMarshaler].
+//
+//	is.Snapshot(value, is.Inline("expected value"))
+//
+// If the test binary is run with the -is.update flag or the IS_UPDATE_GOLDEN=1 environment variable,
+// the string literal passed to [Inline] is replaced with the serialized value in the test source.
+func (is Is) Snapshot(value interface{}, snapshot InlineSnapshot) {
+	s := is.state()
+	s.t.Helper()
+
+	data, err := s.options.marshal(value)
+	if err != nil {
+		is.fail(errSnapshot, fmt.Sprintf("Failed to serialize value: %s", err), "is.Snapshot")
+		return
+	}
+
+	if string(data) == snapshot.expected {
+		return
+	}
+
+	if updateSnapshots() {
+		frame, ok := callerFrame()
+		if !ok {
+			is.fail(errSnapshot, "Failed to find the caller of is.Snapshot", "is.Snapshot")
+		} else if err := rewriteInline(frame.File, frame.Line, string(data)); err != nil {
+			is.fail(errSnapshot, fmt.Sprintf("Failed to update inline snapshot: %s", err), "is.Snapshot")
+		}
+		return
+	}
+
+	is.fail(errSnapshotNotEqual, "Value does not match inline snapshot:\n"+cmpValue(string(data), snapshot.expected, s.options), "is.Snapshot")
+}
+
+// inlineFiles are the source files that contain updated inline snapshots.
+var inlineFiles = struct {
+	sync.Mutex
+	files map[string]*inlineFile
+}{files: map[string]*inlineFile{}}
+
+// inlineFile is a source file that contains inline snapshots.
+// Line numbers reported by the runtime refer to the source the test binary was built from, so all
+// updates are applied to the original source instead of the file on disk. This allows updates from
+// different tests to be merged.
+type inlineFile struct {
+	src  []byte
+	fset *token.FileSet
+	file *ast.File
+
+	// edits maps the offset of a string literal to its replacement.
+	edits map[int]inlineEdit
+}
+
+type inlineEdit struct {
+	start, end int
+	text       string
+}
+
+// rewriteInline replaces the string literal passed to [Inline] in the call to [Is.Snapshot] at the given
+// line with value. This is safe to call concurrently.
+func rewriteInline(path string, line int, value string) error {
+	inlineFiles.Lock()
+	defer inlineFiles.Unlock()
+
+	f, ok := inlineFiles.files[path]
+	if !ok {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		f = &inlineFile{src: src, fset: fset, file: file, edits: map[int]inlineEdit{}}
+		inlineFiles.files[path] = f
+	}
+
+	lit := f.findLiteral(line)
+	if lit == nil {
+		return fmt.Errorf("%s:%d: cannot find a call to Snapshot with a string literal passed to Inline", path, line)
+	}
+
+	start, end := f.fset.Position(lit.Pos()).Offset, f.fset.Position(lit.End()).Offset
+	f.edits[start] = inlineEdit{start: start, end: end, text: quoteInline(value)}
+
+	src, err := format.Source(f.apply())
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, src, info.Mode())
+}
+
+// findLiteral finds the string literal passed to Inline in the call to Snapshot at the given line.
+// If the line contains nested calls, the innermost call is used.
+func (f *inlineFile) findLiteral(line int) (lit *ast.BasicLit) {
+	var span int
+
+	ast.Inspect(f.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || funcName(call.Fun) != "Snapshot" || len(call.Args) != 2 {
+			return true
+		}
+
+		start, end := f.fset.Position(call.Pos()).Line, f.fset.Position(call.End()).Line
+		if line < start || line > end || (lit != nil && end-start >= span) {
+			return true
+		}
+
+		inline, ok := call.Args[1].(*ast.CallExpr)
+		if !ok || funcName(inline.Fun) != "Inline" || len(inline.Args) != 1 {
+			return true
+		}
+
+		if arg, ok := inline.Args[0].(*ast.BasicLit); ok && arg.Kind == token.STRING {
+			lit, span = arg, end-start
+		}
+		return true
+	})
+
+	return lit
+}
+
+// apply applies all edits to the original source.
+func (f *inlineFile) apply() []byte {
+	edits := make([]inlineEdit, 0, len(f.edits))
+	for _, edit := range f.edits {
+		edits = append(edits, edit)
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var b bytes.Buffer
+	last := 0
+	for _, edit := range edits {
+		b.Write(f.src[last:edit.start])
+		b.WriteString(edit.text)
+		last = edit.end
+	}
+	b.Write(f.src[last:])
+
+	return b.Bytes()
+}
+
+// funcName returns the name of the function called by a call expression.
+// This returns an empty string if the function is not an identifier or a selector.
+func funcName(fn ast.Expr) string {
+	switch fn := fn.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// quoteInline returns a Go string literal for s.
+// Multi-line strings are written as raw string literals where possible.
+func quoteInline(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
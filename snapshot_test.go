@@ -0,0 +1,78 @@
+package is
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+const snapshotSource = `package example
+
+func TestExample(t *testing.T) {
+	is := is.New(t)
+	is.Snapshot(1, is.Inline("0"))
+	is.Snapshot(
+		"multi line",
+		is.Inline(""),
+	)
+	is.Equal(1, 1, "")
+}
+`
+
+func TestSnapshot(t *testing.T) {
+	mustPass(t, func(is Is) { is.Snapshot(12, Inline("12")) })
+	mustFail(t, errSnapshotNotEqual, func(is Is) { is.Snapshot(12, Inline("13")) })
+
+	result := internal.Run(func(t internal.T) {
+		newIs(t, newOptions([]Option{GoldenMarshaler(MarshalJSON)})).Snapshot([]int{1}, Inline("[\n\t1\n]\n"))
+	})
+	if result.Failed {
+		t.Fatalf("Snapshot did not use the marshaler set by GoldenMarshaler: %s", result.FailMessage)
+	}
+}
+
+func TestRewriteInline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "example_test.go")
+	if err := os.WriteFile(path, []byte(snapshotSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// rewrites must be merged even though the second rewrite changes the number of lines in the file.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() { defer wg.Done(); errs[0] = rewriteInline(path, 7, "multi\nline") }()
+	go func() { defer wg.Done(); errs[1] = rewriteInline(path, 5, "1") }()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(src), `is.Snapshot(1, is.Inline("1"))`) || !strings.Contains(string(src), "is.Inline(`multi\nline`)") {
+		t.Fatalf("Snapshots were not rewritten:\n%s", src)
+	}
+
+	if err := rewriteInline(path, 10, "1"); err == nil {
+		t.Fatal("rewriteInline did not fail for a line without a snapshot")
+	}
+}
+
+func TestRewriteInlineMissingFile(t *testing.T) {
+	err := rewriteInline(filepath.Join(t.TempDir(), "missing_test.go"), 1, "")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("rewriteInline did not fail for a missing file: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package is
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// NonFatal makes all checks non-fatal.
+// See [Is.Soft] for details.
+func NonFatal() Option {
+	return func(o *options) { o.nonFatal = true }
+}
+
+// softLog records the checks that failed in a non-fatal test.
+type softLog struct {
+	mu       sync.Mutex
+	failures []softFailure
+}
+
+type softFailure struct {
+	site    string
+	message string
+}
+
+// add records a failed check.
+// reason is the reason the check failed and format and args are the user provided message.
+func (l *softLog) add(reason string, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = reason
+	}
+
+	// only the first line is used since the reason may contain a multi-line diff.
+	if i := strings.IndexByte(msg, '\n'); i != -1 {
+		msg = msg[:i]
+	}
+
+	l.mu.Lock()
+	l.failures = append(l.failures, softFailure{site: callSite(), message: msg})
+	l.mu.Unlock()
+}
+
+// report logs a summary of all failed checks.
+func (l *softLog) report(t internal.T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.failures) == 0 {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "is: %d check(s) failed:", len(l.failures))
+	for _, f := range l.failures {
+		fmt.Fprintf(&b, "\n\t%s: %s", f.site, f.message)
+	}
+
+	t.Helper()
+	t.Logf("%s", b.String())
+}
@@ -0,0 +1,57 @@
+package is
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestSoft(t *testing.T) {
+	var reached bool
+	var log *softLog
+
+	result := internal.Run(func(t internal.T) {
+		is := newIs(t, &options{}).Soft()
+		log = is.state().soft
+
+		is(false, "condition")
+		is.Equal(1, 2, "equal")
+		is.Err(errCalledFail, os.ErrClosed, "err")
+		is.Panic(func() {}, "panic")
+		reached = true
+	})
+
+	if !result.Failed {
+		t.Fatal("Test did not fail")
+	}
+	if !reached {
+		t.Fatal("Soft check stopped the test")
+	}
+	if !errors.Is(result.TestError, errFuncNoPanic) {
+		t.Fatalf("Test failed with %s not %s", result.TestError, errFuncNoPanic)
+	}
+	if len(log.failures) != 4 {
+		t.Fatalf("Expected 4 failures to be recorded, got %d", len(log.failures))
+	}
+	if log.failures[1].message != "equal" || log.failures[1].site == "unknown" {
+		t.Fatalf("Incorrect failure recorded: %+v", log.failures[1])
+	}
+	if len(result.CleanupFuncs) != 1 {
+		t.Fatal("Summary cleanup function was not added")
+	}
+}
+
+func TestSoftFail(t *testing.T) {
+	var reached bool
+	result := internal.Run(func(t internal.T) {
+		is := newIs(t, newOptions([]Option{NonFatal()}))
+		is.Fail("fail")
+		reached = true
+	})
+
+	if !result.Failed || reached {
+		t.Fatal("Fail did not stop a non-fatal test")
+	}
+}
@@ -1,10 +1,12 @@
 package is
 
 import (
+	"io"
 	"reflect"
 	"runtime/debug"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/yehan2002/is/v2/internal"
 )
@@ -13,22 +15,77 @@ import (
 // All test functions must have the prefixed with Test and must take [Is] as the first and only argument
 // and should not have a return value.
 // Test functions are run sequentially in lexicographic order. To run tests in parallel use [SuiteP].
+// The order can be changed using the [Shuffle] and [DeclarationOrder] options.
 //
 //	// Example test
 //	func (s *SuiteName) TestName(Is){}
 //
 // A test suite can define a Setup and Teardown function to setup the test suite and cleanup after the
-// suite has completed. Both setup and teardown functions must have no arguments and return values.
-// The Teardown function is always called after running all tests even if the tests fail.
+// suite has completed. Setup and teardown functions may take [Is] as their only argument and may return
+// an error. The Teardown function is always called after running all tests even if the tests or Setup fail.
 //
 //	func (s *suiteName) Setup(){ /* setup the set suite here */ }
 //	func (s *suiteName) Teardown(){ /* clean up after the test suite has completed. */ }
+//
+// If Setup returns an error, panics or a check using the given [Is] fails, all tests in the suite are
+// skipped with the reason Setup failed.
+//
+//	func (s *suiteName) Setup() error { /* setup the set suite here */ }
+//	func (s *suiteName) Teardown(is Is) { /* clean up after the test suite has completed. */ }
+//
+// A test suite can also define SetupTest and TeardownTest functions that are called before and after each
+// test. Both functions must take [Is] as the first and only argument. They are called inside the subtest
+// of each test, and TeardownTest is called even if the test fails. BeforeEach and AfterEach can be used
+// instead of SetupTest and TeardownTest.
+//
+//	func (s *suiteName) SetupTest(is Is){ /* reset fixtures before each test */ }
+//	func (s *suiteName) TeardownTest(is Is){ /* clean up after each test */ }
+//
+// Exported fields tagged with `is:"suite"` are nested suites. Each nested suite is run as a subtest
+// named after the field after the tests of this suite, and has its own Setup and Teardown methods.
+// Nested suites are set up after and torn down before the suite that contains them.
+// Tagged embedded suites are nested suites named after their type, and the methods promoted from them
+// are not used by the suite that embeds them. Embedded suites that are not tagged are not nested.
+// Instead, their tests are promoted to the suite that embeds them.
+//
+//	type storageSuite struct {
+//		Tables *tablesSuite `is:"suite"`
+//	}
+//
+// A test suite can define the following methods to configure how it is run:
+//
+//	func (s *suiteName) Options() []Option { /* options used by all tests in the suite */ }
+//	func (s *suiteName) Parallel() bool { /* run the tests in parallel even when using Suite */ }
+//	func (s *suiteName) Timeout() time.Duration { /* same as passing the Timeout option */ }
+//
+// Options are applied in the following order, with later options taking precedence: the default options,
+// the options returned by Options, the option set by Timeout and the options passed to Suite.
+// Nested suites use the options of the suites that contain them before their own options.
+// Tests are run in parallel if Parallel returns true or if the suite is run using [SuiteP].
+//
+// A test suite can declare the tests each test depends on using a Dependencies method. Tests are run after
+// the tests they depend on, and are skipped if any of them fail or are skipped. Tests that depend on other
+// tests or that other tests depend on are never run in parallel.
+//
+//	func (s *suiteName) Dependencies() map[string][]string {
+//		return map[string][]string{"TestUpdate": {"TestCreate"}}
+//	}
+//
+// suite can also be a function that creates a new suite value. Each test is run on a new value created
+// by calling the function, and Setup and Teardown are called on each value inside the subtest of the test.
+// If Setup fails, only the test using that value is skipped. The function is also called once when the
+// suite is created to get the tests and the configuration of the suite. Setup and Teardown are not called
+// on that value. Instead, if it implements [io.Closer], it is closed once the configuration has been read.
+// Resources used by the tests should be acquired in Setup instead of the function.
+//
+//	is.SuiteP(t, func() *suiteName { return &suiteName{} })
 func Suite(t *testing.T, suite interface{}, opts ...Option) {
 	t.Helper()
 	makeSuite(t, suite, false, opts).Run(t)
 }
 
 // SuiteP like [Suite] but calls all test function in parallel.
+// If the tests modify the suite, pass a function that creates the suite to give each test its own value.
 func SuiteP(t *testing.T, suite interface{}, opts ...Option) {
 	t.Helper()
 	makeSuite(t, suite, true, opts).Run(t)
@@ -38,12 +95,28 @@ type testSuite struct {
 	name     string
 	parallel bool
 
-	setupFunc    func()
-	teardownFunc func()
+	// methods are the Setup and Teardown methods of the suite.
+	// If the suite is created using a factory, these are no-op functions and the methods of each
+	// value returned by the factory are used instead.
+	methods suiteMethods
+
+	// factory creates a new suite value for each test. This is nil if all tests use the same value.
+	factory func() reflect.Value
+
+	// promoted are the methods promoted from embedded nested suites. See [promotedMethods].
+	promoted map[string]bool
 
 	options *options
 
 	tests []*test
+
+	// dependencies are the tests each test depends on.
+	dependencies map[string][]string
+	// hasDependencies contains the tests that depend on other tests or that other tests depend on.
+	hasDependencies map[string]bool
+
+	// suites are the nested suites that are run as subtests of this suite.
+	suites []*testSuite
 }
 
 type test struct {
@@ -53,22 +126,123 @@ type test struct {
 
 func (s *testSuite) Run(t internal.T) {
 	t.Helper()
+	s.run(t, newTestInfo(t, nil, false, 0))
+}
+
+// run runs the suite using t. info is the information of the test that runs the suite.
+func (s *testSuite) run(t internal.T, info *testInfo) {
+	t.Helper()
 
 	// skip suite if it has no tests
-	if len(s.tests) == 0 {
+	if len(s.tests) == 0 && len(s.suites) == 0 {
 		t.Logf("is.Suite: skipped suite '%s' with no tests", s.name)
 		return
 	}
 
-	s.setupFunc()
-	t.Cleanup(s.teardownFunc)
+	ok, setupFailure := s.setup(t, info, s.methods)
+	if !ok {
+		t.Logf("is.Suite: Setup failed. Skipping all tests in suite '%s':\n%s", s.name, setupFailure)
+	}
+
+	// outcomes are the reasons tests that other tests depend on did not pass.
+	// Tests with dependencies are never run in parallel, so each outcome is recorded after the test and
+	// its cleanups have finished.
+	outcomes := map[string]string{}
 
 	for i := range s.tests {
 		test := s.tests[i]
-		runT(t, s.options, test.Name, s.parallel, test.Func)
+
+		// tests with dependencies are run sequentially so that they are run after the tests they depend on.
+		parallel := s.parallel && !s.hasDependencies[test.Name]
+		subtest := runT(t, info, s.options, test.Name, parallel, s.options.timeout, func(is Is) {
+			if !ok {
+				// return in case Skipf does not stop the test.
+				is.t().Skipf("is.Suite: Setup failed:\n%s", setupFailure)
+				return
+			}
+
+			for _, dependency := range s.dependencies[test.Name] {
+				if outcome := outcomes[dependency]; outcome != "" {
+					is.t().Skipf("is.Suite: Skipping %s because %s %s", test.Name, dependency, outcome)
+					return
+				}
+			}
+
+			s.methods.runTest(is, test.Func)
+		})
+
+		if s.hasDependencies[test.Name] {
+			outcomes[test.Name] = testOutcome(subtest)
+		}
+	}
+
+	for i := range s.suites {
+		nested := s.suites[i]
+		runT(t, info, s.options, nested.name, s.parallel, 0, func(is Is) {
+			if !ok {
+				is.t().Skipf("is.Suite: Setup failed:\n%s", setupFailure)
+				return
+			}
+
+			// the nested suite uses the test of this subtest so that it inherits the context and
+			// whether the test is parallel from this suite.
+			nested.run(is.t(), is.state().test)
+		})
 	}
 }
 
+// runInstance runs the test with the given method index on a new suite value created by the factory.
+func (s *testSuite) runInstance(is Is, method int) {
+	t := is.t()
+	t.Helper()
+
+	instance := s.factory()
+	if isNil(instance) {
+		is.Fail("is.Suite: suite factory returned nil")
+	}
+
+	// the signatures of the methods were checked when the suite was created.
+	methods := getSuiteMethods(nil, instance, s.promoted)
+	if ok, failure := s.setup(t, is.state().test, methods); !ok {
+		t.Skipf("is.Suite: Setup failed:\n%s", failure)
+		return
+	}
+
+	methods.runTest(is, instance.Method(method).Interface().(func(Is)))
+}
+
+// setup calls the Setup method and registers the Teardown method to be called when t completes.
+// This reports if Setup succeeded and why it failed.
+func (s *testSuite) setup(t internal.T, info *testInfo, methods suiteMethods) (ok bool, failure string) {
+	// register the teardown function first so that it is called even if Setup fails.
+	t.Cleanup(func() {
+		if ok, failure := s.runLifecycle(t, info, methods.teardown); !ok {
+			if internal, ok := t.(*internal.Test); ok {
+				internal.SetError(errSuiteTeardown)
+			}
+			t.Errorf("is.Suite: Teardown failed:\n%s", failure)
+		}
+	})
+
+	return s.runLifecycle(t, info, methods.setup)
+}
+
+// runLifecycle calls the given Setup or Teardown function.
+// Failed checks, errors and panics do not fail t. Instead, this reports if fn succeeded and why it failed.
+func (s *testSuite) runLifecycle(t internal.T, info *testInfo, fn func(Is) error) (ok bool, failure string) {
+	opts := s.options.clone()
+	opts.nonFatal = false
+
+	rt := &recordT{T: t}
+	rt.runRecover(func() {
+		if err := fn(newState(rt, opts, info).Is); err != nil {
+			rt.record(err.Error())
+		}
+	})
+
+	return !rt.failed, rt.String()
+}
+
 func makeSuite(t internal.T, s interface{}, parallel bool, opts []Option) (testS *testSuite) {
 	// calledFatal indicates that t.Fatal was called.
 	// This is used to differentiate between t.Fatal calling runtime.Goexit and a panic in the code bellow.
@@ -97,17 +271,46 @@ func makeSuite(t internal.T, s interface{}, parallel bool, opts []Option) (testS
 		t.Fatalf(f, args...)
 	}
 
-	options := newOptions(opts)
+	t.Helper()
+
+	// the environment variable is applied before the options passed to Suite so that they take precedence.
+	shuffle, err := shuffleEnv()
+	if err != nil {
+		fatal(errShuffleSeed, "is.Suite: %s", err)
+	}
+	opts = append([]Option{shuffle}, opts...)
+
+	return buildSuite(t, fatal, reflect.ValueOf(s), parallel, nil, opts, map[suiteKey]bool{})
+}
 
+// suiteKey identifies a suite value. The type is needed because a struct and its first field have
+// the same address.
+type suiteKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// buildSuite creates a test suite from the given value.
+// inherited are the options returned by the Options methods of the suites that contain this suite and
+// opts are the options passed to [Suite]. parents are the suites that contain this suite. This is used
+// to detect nested suites that contain themselves.
+func buildSuite(t internal.T, fatal func(err error, f string, a ...interface{}), suite reflect.Value, parallel bool, inherited, opts []Option, parents map[suiteKey]bool) (testS *testSuite) {
 	t.Helper()
 
-	suite := reflect.ValueOf(s)
 	if isNil(suite) {
 		fatal(errNilSuite, "is.Suite: test suite is nil.")
 	}
 
+	var factory func() reflect.Value
+	if suite.Kind() == reflect.Func {
+		factory, suite = getFactory(fatal, suite)
+	}
+
 	suiteType := suite.Type()
-	testS = &testSuite{name: suite.Type().Name(), parallel: parallel, options: options}
+	testS = &testSuite{name: suiteType.Name(), parallel: parallel, factory: factory, promoted: promotedMethods(suiteType)}
+	if suiteType.Kind() == reflect.Pointer {
+		testS.name = suiteType.Elem().Name()
+	}
 
 	// check if the caller passed a value instead of a pointer to a value by accident.
 	// If any methods on the type have a pointer receiver, they cannot be called because `suite` is not
@@ -125,24 +328,34 @@ func makeSuite(t internal.T, s interface{}, parallel bool, opts []Option) (testS
 
 			// check if the method has a pointer receiver.
 			if methodType.In(0) == suitePtr {
-				if n := method.Name; n == "Setup" || n == "Teardown" || strings.HasPrefix(n, "Test") {
+				if n := method.Name; isSuiteMethod(n) || strings.HasPrefix(n, "Test") {
 					fatal(errReceiver, "is.Suite: Method %s has a pointer receiver but Suite was given a %s not *%s.", n, testS.name, testS.name)
 				}
 			}
 		}
 	}
 
+	// apply the options defined by the suite. Options passed to Suite take precedence.
+	metadata := getSuiteMetadata(fatal, suite, testS.promoted)
+	inherited = append(inherited[:len(inherited):len(inherited)], metadata.options...)
+	testS.options = newOptions(append(inherited[:len(inherited):len(inherited)], opts...))
+	testS.parallel = parallel || metadata.parallel
+
 	// get setup and teardown functions
-	testS.setupFunc = getMethod(fatal, suite, "Setup")
-	testS.teardownFunc = getMethod(fatal, suite, "Teardown")
+	testS.methods = getSuiteMethods(fatal, suite, testS.promoted)
+	if factory != nil {
+		// the methods of each value created by the factory are used instead.
+		noop := func(Is) error { return nil }
+		testS.methods = suiteMethods{setup: noop, teardown: noop}
+	}
 
 	// get all tests defined by the suite.
 	for i := 0; i < suite.NumMethod(); i++ {
 		methodValue := suite.Method(i)
 		name := suiteType.Method(i).Name
 
-		// ignore unexported methods
-		if !strings.HasPrefix(name, "Test") || !methodValue.CanInterface() {
+		// ignore unexported methods and tests of embedded nested suites.
+		if !strings.HasPrefix(name, "Test") || !methodValue.CanInterface() || testS.promoted[name] {
 			continue
 		}
 
@@ -152,26 +365,277 @@ func makeSuite(t internal.T, s interface{}, parallel bool, opts []Option) (testS
 			continue
 		}
 
+		if factory != nil {
+			method := i
+			testFunc = func(is Is) { testS.runInstance(is, method) }
+		}
+
 		testS.tests = append(testS.tests, &test{Name: name, Func: testFunc})
 	}
 
+	testS.sortTests(t, suiteType)
+	testS.sortDependencies(fatal, metadata.dependencies)
+
+	if factory != nil {
+		closePrototype(fatal, suite)
+	}
+
+	if suite.Kind() != reflect.Pointer || suite.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	key := suiteKey{ptr: suite.Pointer(), typ: suiteType}
+	parents[key] = true
+	defer delete(parents, key)
+
+	// get nested suites.
+	for i := 0; i < suite.Elem().NumField(); i++ {
+		field := suiteType.Elem().Field(i)
+		if field.Tag.Get("is") != "suite" {
+			continue
+		}
+
+		switch {
+		case factory != nil:
+			fatal(errNestedSuite, "is.Suite: Nested suite %s cannot be used with a suite factory.", field.Name)
+		case field.PkgPath != "":
+			fatal(errNestedSuite, "is.Suite: Nested suite %s must be an exported field.", field.Name)
+		}
+
+		nested := suite.Elem().Field(i)
+		if nested.Kind() != reflect.Pointer {
+			nested = nested.Addr()
+		} else if nested.IsNil() {
+			fatal(errNilSuite, "is.Suite: Nested suite %s is nil.", field.Name)
+		} else if parents[suiteKey{ptr: nested.Pointer(), typ: nested.Type()}] {
+			fatal(errNestedSuite, "is.Suite: Nested suite %s contains itself.", field.Name)
+		}
+
+		nestedSuite := buildSuite(t, fatal, nested, testS.parallel, inherited, opts, parents)
+		nestedSuite.name = field.Name
+		testS.suites = append(testS.suites, nestedSuite)
+	}
+
 	return
 }
 
+// promotedMethods gets the names of the methods of typ that are promoted from embedded fields with the
+// `is:"suite"` tag. These methods belong to the nested suite and are not used by the suite that embeds it.
+func promotedMethods(typ reflect.Type) (promoted map[string]bool) {
+	if typ.Kind() != reflect.Pointer || typ.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < typ.Elem().NumField(); i++ {
+		field := typ.Elem().Field(i)
+		if !field.Anonymous || field.Tag.Get("is") != "suite" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() != reflect.Pointer {
+			fieldType = reflect.PointerTo(fieldType)
+		}
+
+		for j := 0; j < fieldType.NumMethod(); j++ {
+			name := fieldType.Method(j).Name
+
+			// methods declared by the suite itself take precedence over the methods of the field.
+			// Promoted methods have no declaration or the declaration of the method of the field.
+			if d := methodDeclaration(typ, name); d.file != "" && d != methodDeclaration(fieldType, name) {
+				continue
+			}
+
+			if promoted == nil {
+				promoted = map[string]bool{}
+			}
+			promoted[name] = true
+		}
+	}
+	return promoted
+}
+
+// getFactory checks that fn is a function that creates suites.
+// This returns a function that calls fn and a suite value created using it. The methods of this value are
+// used to get the tests and the configuration of the suite. Suites that are not pointers are copied to a
+// new pointer so that methods with a pointer receiver can be called.
+func getFactory(fatal func(err error, f string, a ...interface{}), fn reflect.Value) (factory func() reflect.Value, prototype reflect.Value) {
+	fnType := fn.Type()
+	if fnType.NumIn() != 0 || fnType.NumOut() != 1 || fnType.Out(0).Kind() == reflect.Interface {
+		fatal(errSuiteFactory, "is.Suite: suite factory must be a func() S that returns a concrete type not %s", fnType)
+	}
+
+	suiteType := fnType.Out(0)
+	factory = func() reflect.Value { return fn.Call(nil)[0] }
+	if suiteType.Kind() != reflect.Pointer {
+		factory = func() reflect.Value {
+			v := reflect.New(suiteType)
+			v.Elem().Set(fn.Call(nil)[0])
+			return v
+		}
+	}
+
+	if prototype = factory(); isNil(prototype) {
+		fatal(errSuiteFactory, "is.Suite: suite factory returned nil")
+	}
+	return factory, prototype
+}
+
+// closePrototype closes the suite value created by [getFactory] to get the configuration of the suite
+// if it implements [io.Closer]. This value is not used after the configuration is read.
+func closePrototype(fatal func(err error, f string, a ...interface{}), prototype reflect.Value) {
+	if closer, ok := prototype.Interface().(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			fatal(errSuiteFactory, "is.Suite: failed to close the suite used to get the configuration of the suite: %s", err)
+		}
+	}
+}
+
+// suiteMetadata is the configuration defined by the metadata methods of a suite.
+type suiteMetadata struct {
+	options      []Option
+	parallel     bool
+	dependencies map[string][]string
+}
+
+// getSuiteMetadata calls the Options, Parallel, Timeout and Dependencies methods of v.
+// This function calls fatal if any of the methods have an incorrect signature.
+func getSuiteMetadata(fatal func(err error, f string, a ...interface{}), v reflect.Value, promoted map[string]bool) (m suiteMetadata) {
+	if method := suiteMethod(v, promoted, "Options"); method.IsValid() {
+		Func, ok := method.Interface().(func() []Option)
+		if !ok {
+			fatal(errMethodSignature, "is.Suite: Options method should be a func() []is.Option")
+		}
+		m.options = Func()
+	}
+
+	if method := suiteMethod(v, promoted, "Parallel"); method.IsValid() {
+		Func, ok := method.Interface().(func() bool)
+		if !ok {
+			fatal(errMethodSignature, "is.Suite: Parallel method should be a func() bool")
+		}
+		m.parallel = Func()
+	}
+
+	if method := suiteMethod(v, promoted, "Timeout"); method.IsValid() {
+		Func, ok := method.Interface().(func() time.Duration)
+		if !ok {
+			fatal(errMethodSignature, "is.Suite: Timeout method should be a func() time.Duration")
+		}
+		m.options = append(m.options, Timeout(Func()))
+	}
+
+	if method := suiteMethod(v, promoted, "Dependencies"); method.IsValid() {
+		Func, ok := method.Interface().(func() map[string][]string)
+		if !ok {
+			fatal(errMethodSignature, "is.Suite: Dependencies method should be a func() map[string][]string")
+		}
+		m.dependencies = Func()
+	}
+
+	return
+}
+
+// suiteMethods are the Setup and Teardown methods of a suite value.
+type suiteMethods struct {
+	setup    func(Is) error
+	teardown func(Is) error
+
+	setupTest    func(Is)
+	teardownTest func(Is)
+}
+
+// getSuiteMethods gets the Setup and Teardown methods of v. See [getMethod] and [getTestMethod].
+// promoted are the methods of v that are promoted from embedded nested suites.
+func getSuiteMethods(fatal func(err error, f string, a ...interface{}), v reflect.Value, promoted map[string]bool) (m suiteMethods) {
+	m.setup = getMethod(fatal, v, promoted, "Setup")
+	m.teardown = getMethod(fatal, v, promoted, "Teardown")
+	m.setupTest = getTestMethod(fatal, v, promoted, "SetupTest", "BeforeEach")
+	m.teardownTest = getTestMethod(fatal, v, promoted, "TeardownTest", "AfterEach")
+	return
+}
+
+// runTest runs fn surrounded by the SetupTest and TeardownTest methods.
+func (m suiteMethods) runTest(is Is, fn func(Is)) {
+	// register the teardown function first so that it is called even if SetupTest fails.
+	if m.teardownTest != nil {
+		is.t().Cleanup(func() { m.teardownTest(is) })
+	}
+
+	if m.setupTest != nil {
+		m.setupTest(is)
+	}
+
+	fn(is)
+}
+
 // getMethod gets the method of v that has the given name.
+// The method may be a func(), func() error, func(Is) or func(Is) error.
 // If the method does not exist, an no-op function is returned instead.
-// This function calls fatal if the method exists but isn't the same type as F.
-func getMethod(fatal func(err error, f string, a ...interface{}), v reflect.Value, name string) (F func()) {
-	method := v.MethodByName(name)
-	if method.IsValid() {
-		Func, ok := method.Interface().(func())
+// This function calls fatal if the method exists but has a different signature.
+func getMethod(fatal func(err error, f string, a ...interface{}), v reflect.Value, promoted map[string]bool, name string) (F func(Is) error) {
+	method := suiteMethod(v, promoted, name)
+	if !method.IsValid() {
+		return func(Is) error { return nil }
+	}
+
+	switch Func := method.Interface().(type) {
+	case func():
+		return func(Is) error { Func(); return nil }
+	case func() error:
+		return func(Is) error { return Func() }
+	case func(Is):
+		return func(is Is) error { Func(is); return nil }
+	case func(Is) error:
+		return Func
+	}
+
+	fatal(errMethodSignature, "is.Suite: %s method should be a func(), func() error, func(Is) or func(Is) error", name)
+	return nil
+}
+
+// getTestMethod gets the method of v that has one of the given names and is called for each test.
+// If none of the methods exist, nil is returned instead.
+// This function calls fatal if the method is not a func(Is) or if more than one of the methods exist.
+func getTestMethod(fatal func(err error, f string, a ...interface{}), v reflect.Value, promoted map[string]bool, names ...string) (F func(Is)) {
+	var found string
+	for _, name := range names {
+		method := suiteMethod(v, promoted, name)
+		if !method.IsValid() {
+			continue
+		}
+
+		if found != "" {
+			fatal(errMethodSignature, "is.Suite: %s and %s cannot both be defined", found, name)
+		}
+
+		Func, ok := method.Interface().(func(Is))
 		if !ok {
-			fatal(errMethodSignature, "is.Suite: %s method should have no arguments and return values", name)
+			fatal(errMethodSignature, "is.Suite: %s method should take Is as the only argument and have no return values", name)
 		}
-		return Func
+
+		F, found = Func, name
+	}
+
+	return F
+}
+
+// suiteMethod gets the method of the suite v that has the given name.
+// This returns the zero [reflect.Value] if the method does not exist or is promoted from an embedded nested suite.
+func suiteMethod(v reflect.Value, promoted map[string]bool, name string) reflect.Value {
+	if promoted[name] {
+		return reflect.Value{}
 	}
+	return v.MethodByName(name)
+}
 
-	return func() {}
+// isSuiteMethod checks if name is the name of a method that is called by the suite.
+func isSuiteMethod(name string) bool {
+	switch name {
+	case "Setup", "Teardown", "SetupTest", "TeardownTest", "BeforeEach", "AfterEach", "Options", "Parallel", "Timeout", "Dependencies":
+		return true
+	}
+	return false
 }
 
 func isNil(v reflect.Value) (isNil bool) {
@@ -2,7 +2,10 @@ package is
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/yehan2002/is/v2/internal"
 )
@@ -27,9 +30,47 @@ type testSetupTeardown struct {
 func (t *testSetupTeardown) Teardown() {}
 func (t *testSetupTeardown) Setup()    { t.setupCalled = true }
 
+type testSetupTest struct {
+	testTest
+	calls []string
+}
+
+func (t *testSetupTest) SetupTest(Is)    { t.calls = append(t.calls, "setup") }
+func (t *testSetupTest) TeardownTest(Is) { t.calls = append(t.calls, "teardown") }
+
+type testBeforeEach struct {
+	testTest
+	calls []string
+}
+
+func (t *testBeforeEach) BeforeEach(Is) { t.calls = append(t.calls, "before") }
+func (t *testBeforeEach) AfterEach(Is)  { t.calls = append(t.calls, "after") }
+
+type testSetupTestConflict struct{ testBeforeEach }
+
+func (t *testSetupTestConflict) SetupTest(Is) {}
+
+type testSetupTestIncorrect struct{ testTest }
+
+func (t *testSetupTestIncorrect) TeardownTest() {}
+
 type testSetupTeardownIncorrect struct{}
 
-func (t *testSetupTeardownIncorrect) Setup() error { return nil }
+func (t *testSetupTeardownIncorrect) Setup(int) {}
+
+type testSetupFails struct {
+	setup           func(is Is) error
+	teardownCalled  bool
+	testCalled      bool
+	teardownFailure error
+}
+
+func (t *testSetupFails) Setup(is Is) error { return t.setup(is) }
+func (t *testSetupFails) Teardown() error {
+	t.teardownCalled = true
+	return t.teardownFailure
+}
+func (t *testSetupFails) TestA(Is) { t.testCalled = true }
 
 func TestSuiteNil(t *testing.T) {
 	result := internal.Run(func(t internal.T) { makeSuite(t, nil, false, nil) })
@@ -116,3 +157,455 @@ func TestSuiteReceiver(t *testing.T) {
 		t.Fatalf("allowed suite with pointer receivers to be created from struct value")
 	}
 }
+
+func TestSuiteSetupTest(t *testing.T) {
+	suite := &testSetupTest{}
+	result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+	if result.Failed {
+		t.Fatalf("failed to run suite: %s", result.FailMessage)
+	}
+	runCleanup(result)
+
+	if len(suite.calls) != 8 || suite.calls[0] != "setup" || suite.calls[7] != "teardown" {
+		t.Fatalf("SetupTest and TeardownTest were not called for each test: %v", suite.calls)
+	}
+
+	// TeardownTest must be called even if the test fails.
+	suite = &testSetupTest{testTest: testTest{testBFails: true}}
+	result = internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+	if !result.Failed {
+		t.Fatal("Test should fail")
+	}
+	runCleanup(result)
+
+	if expected := []string{"setup", "setup", "teardown", "teardown"}; strings.Join(suite.calls, ",") != strings.Join(expected, ",") {
+		t.Fatalf("TeardownTest was not called for the failed test: %v", suite.calls)
+	}
+
+	aliases := &testBeforeEach{}
+	result = internal.Run(func(t internal.T) { makeSuite(t, aliases, false, nil).Run(t) })
+	runCleanup(result)
+	if result.Failed || len(aliases.calls) != 8 {
+		t.Fatalf("BeforeEach and AfterEach were not called for each test: %v", aliases.calls)
+	}
+
+	for _, invalid := range []interface{}{&testSetupTestConflict{}, &testSetupTestIncorrect{}} {
+		result = internal.Run(func(t internal.T) { makeSuite(t, invalid, false, nil) })
+		if !result.Failed || !errors.Is(result.TestError, errMethodSignature) {
+			t.Fatalf("allowed test suite with invalid per-test methods: %T", invalid)
+		}
+	}
+}
+
+func TestSuiteSetupFails(t *testing.T) {
+	setups := map[string]func(is Is) error{
+		"setup error":    func(Is) error { return errors.New("setup error") },
+		"setup check":    func(is Is) error { is(false, "setup check"); return nil },
+		"setup panicked": func(Is) error { panic("setup panicked") },
+	}
+
+	for reason, setup := range setups {
+		suite := &testSetupFails{setup: setup}
+		result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+		if result.Failed {
+			t.Fatalf("Suite failed: %s", result.FailMessage)
+		}
+
+		if suite.testCalled || !result.Skipped || len(result.SkipMessage) != 1 || !strings.Contains(result.SkipMessage[0], reason) {
+			t.Fatalf("Tests were not skipped with the reason %q: %s", reason, result.SkipMessage)
+		}
+
+		runCleanup(result)
+		if !suite.teardownCalled {
+			t.Fatal("Teardown was not called after Setup failed")
+		}
+	}
+
+	suite := &testSetupFails{setup: func(Is) error { return nil }, teardownFailure: errors.New("teardown error")}
+	result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+	if result.Failed || !suite.testCalled || result.Skipped {
+		t.Fatalf("Suite did not run tests after Setup succeeded: %s", result.FailMessage)
+	}
+
+	runCleanup(result)
+	if !result.Failed || !errors.Is(result.TestError, errSuiteTeardown) || !strings.Contains(result.FailMessage[0], "teardown error") {
+		t.Fatalf("Teardown error was not reported: %s", result.FailMessage)
+	}
+}
+
+type testFactory struct {
+	id     int
+	events *[]string
+	state  int
+}
+
+func (t *testFactory) Setup() error {
+	*t.events = append(*t.events, fmt.Sprintf("setup %d", t.id))
+	if t.id == 4 {
+		return errors.New("setup error")
+	}
+	return nil
+}
+
+func (t *testFactory) Teardown() { *t.events = append(*t.events, fmt.Sprintf("teardown %d", t.id)) }
+
+func (t *testFactory) TestA(is Is) {
+	t.state++
+	*t.events = append(*t.events, fmt.Sprintf("test %d", t.id))
+	is(t.state == 1, "suite was shared between tests")
+}
+
+func (t *testFactory) TestB(is Is) { t.TestA(is) }
+
+func (t *testFactory) TestC(is Is) { t.TestA(is) }
+
+type testFactoryMetadata struct{ timeout time.Duration }
+
+func (t *testFactoryMetadata) Timeout() time.Duration { return t.timeout }
+func (t *testFactoryMetadata) TestA(Is)               {}
+
+type testFactoryClose struct {
+	closed *int
+	err    error
+}
+
+func (t *testFactoryClose) Close() error { *t.closed++; return t.err }
+func (t *testFactoryClose) TestA(Is)     {}
+
+func TestSuiteFactory(t *testing.T) {
+	var events []string
+	var created int
+	factory := func() *testFactory {
+		created++
+		return &testFactory{id: created, events: &events}
+	}
+
+	result := internal.Run(func(t internal.T) { makeSuite(t, factory, false, nil).Run(t) })
+	if result.Failed {
+		t.Fatalf("failed to run suite: %s", result.FailMessage)
+	}
+
+	// the factory is also called once when the suite is created.
+	if created != 4 || !result.Skipped || len(result.SkipMessage) != 1 || !strings.Contains(result.SkipMessage[0], "setup error") {
+		t.Fatalf("Factory was not called for each test: %v %v", events, result.SkipMessage)
+	}
+
+	runCleanup(result)
+	expected := "setup 2,test 2,setup 3,test 3,setup 4,teardown 4,teardown 3,teardown 2"
+	if got := strings.Join(events, ","); got != expected {
+		t.Fatalf("Setup and Teardown were not called for each value:\n%s\n%s", got, expected)
+	}
+
+	// values returned by the factory must be addressable.
+	result = internal.Run(func(t internal.T) { makeSuite(t, func() testTest { return testTest{} }, false, nil).Run(t) })
+	if result.Failed || len(result.RunTests) != 4 {
+		t.Fatalf("failed to run suite created from a value: %s", result.FailMessage)
+	}
+
+	result = internal.Run(func(t internal.T) { makeSuite(t, func() *testTest { return nil }, false, nil).Run(t) })
+	if !result.Failed || !errors.Is(result.TestError, errSuiteFactory) {
+		t.Fatalf("allowed factory to return nil")
+	}
+
+	// the configuration of the suite is read from a value created by the factory.
+	suite := makeSuite(&internal.Test{}, func() *testFactoryMetadata { return &testFactoryMetadata{timeout: time.Second} }, false, nil)
+	if suite.options.timeout != time.Second {
+		t.Fatalf("Suite metadata was not read from a value created by the factory")
+	}
+
+	// the value used to get the configuration is closed. Values used by tests are not.
+	var closed int
+	result = internal.Run(func(t internal.T) {
+		makeSuite(t, func() *testFactoryClose { return &testFactoryClose{closed: &closed} }, false, nil).Run(t)
+	})
+	if result.Failed || closed != 1 {
+		t.Fatalf("Suite value used to get the configuration was not closed: %d %s", closed, result.FailMessage)
+	}
+
+	result = internal.Run(func(t internal.T) {
+		makeSuite(t, func() *testFactoryClose { return &testFactoryClose{closed: &closed, err: errors.New("close")} }, false, nil)
+	})
+	if !result.Failed || !errors.Is(result.TestError, errSuiteFactory) {
+		t.Fatalf("Error closing the suite value was not reported")
+	}
+
+	for _, invalid := range []interface{}{func(int) *testTest { return nil }, func() interface{} { return nil }, func() {}} {
+		result = internal.Run(func(t internal.T) { makeSuite(t, invalid, false, nil) })
+		if !result.Failed || !errors.Is(result.TestError, errSuiteFactory) {
+			t.Fatalf("allowed invalid suite factory: %T", invalid)
+		}
+	}
+}
+
+type testFactoryParallel struct{ state int }
+
+func (t *testFactoryParallel) Setup()         { t.state = 1 }
+func (t *testFactoryParallel) TestA(is Is)    { t.state++; is.Equal(t.state, 2, "") }
+func (t *testFactoryParallel) TestB(is Is)    { t.TestA(is) }
+func (t *testFactoryParallel) TestC(is Is)    { t.TestA(is) }
+func (t *testFactoryParallel) Teardown(is Is) { is.Equal(t.state, 2, "") }
+
+func TestSuiteFactoryParallel(t *testing.T) {
+	SuiteP(t, func() *testFactoryParallel { return &testFactoryParallel{} })
+}
+
+type testNestedIndexes struct{ events *[]string }
+
+func (t *testNestedIndexes) Setup()       { *t.events = append(*t.events, "setup indexes") }
+func (t *testNestedIndexes) Teardown()    { *t.events = append(*t.events, "teardown indexes") }
+func (t *testNestedIndexes) TestIndex(Is) { *t.events = append(*t.events, "test indexes") }
+
+type testNestedTables struct {
+	events  *[]string
+	Indexes *testNestedIndexes `is:"suite"`
+}
+
+func (t *testNestedTables) Setup()       { *t.events = append(*t.events, "setup tables") }
+func (t *testNestedTables) Teardown()    { *t.events = append(*t.events, "teardown tables") }
+func (t *testNestedTables) TestTable(Is) { *t.events = append(*t.events, "test tables") }
+
+type testNestedStorage struct {
+	events *[]string
+	Tables testNestedTables `is:"suite"`
+	Other  testTest
+	setup  func() error
+}
+
+func (t *testNestedStorage) Setup() error {
+	*t.events = append(*t.events, "setup storage")
+	if t.setup != nil {
+		return t.setup()
+	}
+	return nil
+}
+func (t *testNestedStorage) Teardown()      { *t.events = append(*t.events, "teardown storage") }
+func (t *testNestedStorage) TestStorage(Is) { *t.events = append(*t.events, "test storage") }
+
+type testNestedCycle struct {
+	Self *testNestedCycle `is:"suite"`
+}
+
+func (t *testNestedCycle) TestA(Is) {}
+
+type testNestedUnexported struct {
+	testTest
+	tables *testNestedTables `is:"suite"`
+}
+
+type testNestedEmbeddedUnexported struct {
+	*testTest `is:"suite"`
+}
+
+// NestedColumns is exported so that it can be embedded as a nested suite.
+type NestedColumns struct{ events *[]string }
+
+func (t *NestedColumns) Setup()        { *t.events = append(*t.events, "setup columns") }
+func (t *NestedColumns) TestColumn(Is) { *t.events = append(*t.events, "test columns") }
+
+type testNestedEmbedded struct {
+	events         *[]string
+	*NestedColumns `is:"suite"`
+}
+
+func (t *testNestedEmbedded) Setup()        { *t.events = append(*t.events, "setup schema") }
+func (t *testNestedEmbedded) TestSchema(Is) { *t.events = append(*t.events, "test schema") }
+
+type testNestedInfo struct {
+	Inner *testNestedInfoInner `is:"suite"`
+}
+
+func (t *testNestedInfo) TestOuter(Is) {}
+
+type testNestedInfoInner struct{ info *testInfo }
+
+func (t *testNestedInfoInner) TestInner(is Is) { t.info = is.state().test }
+
+func TestSuiteNested(t *testing.T) {
+	var events []string
+	suite := &testNestedStorage{events: &events, Tables: testNestedTables{events: &events, Indexes: &testNestedIndexes{events: &events}}}
+
+	result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+	if result.Failed {
+		t.Fatalf("failed to run suite: %s", result.FailMessage)
+	}
+	runCleanup(result)
+
+	var names []string
+	for _, test := range result.RunTests {
+		names = append(names, test.Name)
+	}
+	if got := strings.Join(names, ","); got != "TestStorage,Tables,TestTable,Indexes,TestIndex" {
+		t.Fatalf("Nested suites were not run as subtests: %s", got)
+	}
+
+	expected := "setup storage,test storage,setup tables,test tables,setup indexes,test indexes,teardown indexes,teardown tables,teardown storage"
+	if got := strings.Join(events, ","); got != expected {
+		t.Fatalf("Nested suites were not run inside the parent's lifecycle:\n%s\n%s", got, expected)
+	}
+
+	// nested suites must be skipped if the parent's setup fails.
+	events = nil
+	suite.setup = func() error { return errors.New("setup error") }
+	result = internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil).Run(t) })
+	runCleanup(result)
+	if result.Failed || len(result.SkipMessage) != 2 || strings.Join(events, ",") != "setup storage,teardown storage" {
+		t.Fatalf("Nested suites were not skipped after setup failed: %v", events)
+	}
+
+	// tests and methods promoted from embedded nested suites belong to the nested suite.
+	events = nil
+	result = internal.Run(func(t internal.T) {
+		makeSuite(t, &testNestedEmbedded{events: &events, NestedColumns: &NestedColumns{events: &events}}, false, nil).Run(t)
+	})
+	runCleanup(result)
+
+	names = nil
+	for _, test := range result.RunTests {
+		names = append(names, test.Name)
+	}
+	if got := strings.Join(names, ","); result.Failed || got != "TestSchema,NestedColumns,TestColumn" {
+		t.Fatalf("Embedded nested suite was not run as a subtest: %s %s", got, result.FailMessage)
+	}
+	if got := strings.Join(events, ","); got != "setup schema,test schema,setup columns,test columns" {
+		t.Fatalf("Methods of the embedded nested suite were used by the parent: %s", got)
+	}
+
+	// nested suites are run using the test of the parent suite.
+	nestedInfo := &testNestedInfo{Inner: &testNestedInfoInner{}}
+	result = internal.Run(func(t internal.T) { makeSuite(t, nestedInfo, true, nil).Run(t) })
+	runCleanup(result)
+
+	var root *testInfo
+	for info := nestedInfo.Inner.info; info != nil; info = info.parent {
+		root = info
+	}
+	if result.Failed || root == nil || root.t != result || !nestedInfo.Inner.info.parent.isParallel() {
+		t.Fatalf("Nested suite was not run using the test of the parent suite: %s", result.FailMessage)
+	}
+
+	cycle := &testNestedCycle{}
+	cycle.Self = cycle
+
+	invalid := []struct {
+		suite interface{}
+		err   error
+	}{
+		{cycle, errNestedSuite},
+		{&testNestedCycle{}, errNilSuite},
+		{&testNestedUnexported{}, errNestedSuite},
+		{&testNestedEmbeddedUnexported{}, errNestedSuite},
+		{func() *testNestedStorage { return &testNestedStorage{} }, errNestedSuite},
+	}
+
+	for _, c := range invalid {
+		result = internal.Run(func(t internal.T) { makeSuite(t, c.suite, false, nil) })
+		if !result.Failed || !errors.Is(result.TestError, c.err) {
+			t.Fatalf("allowed invalid nested suite %T: %s", c.suite, result.TestError)
+		}
+	}
+}
+
+type testMetadata struct {
+	testTest
+	Nested *testMetadataNested `is:"suite"`
+}
+
+func (t *testMetadata) Options() []Option {
+	return []Option{EquateEmpty(false), EquateNaN(false), Timeout(time.Second)}
+}
+func (t *testMetadata) Parallel() bool         { return true }
+func (t *testMetadata) Timeout() time.Duration { return 2 * time.Second }
+
+type testMetadataNested struct{ testTest }
+
+func (t *testMetadataNested) Options() []Option { return []Option{EquateEmpty(true)} }
+
+type testOptionsIncorrect struct{ testTest }
+
+func (t *testOptionsIncorrect) Options() []int { return nil }
+
+type testParallelIncorrect struct{ testTest }
+
+func (t *testParallelIncorrect) Parallel() {}
+
+type testTimeoutIncorrect struct{ testTest }
+
+func (t *testTimeoutIncorrect) Timeout() int { return 0 }
+
+func TestSuiteMetadata(t *testing.T) {
+	var suite *testSuite
+	result := internal.Run(func(t internal.T) {
+		suite = makeSuite(t, &testMetadata{Nested: &testMetadataNested{}}, false, []Option{EquateNaN(true)})
+		suite.Run(t)
+	})
+	if result.Failed {
+		t.Fatalf("failed to run suite: %s", result.FailMessage)
+	}
+
+	if o := suite.options; o.equateEmpty || !o.equateNaN || o.timeout != 2*time.Second || !suite.parallel {
+		t.Fatalf("Suite options were not applied in the correct order: %+v", o)
+	}
+
+	for _, test := range result.RunTests {
+		if !test.Parallel {
+			t.Fatalf("Test %s was not run in parallel", test.Name)
+		}
+	}
+
+	if o := suite.suites[0].options; !o.equateEmpty || !o.equateNaN || o.timeout != 2*time.Second || !suite.suites[0].parallel {
+		t.Fatalf("Nested suite did not inherit options: %+v", o)
+	}
+
+	result = internal.Run(func(t internal.T) { suite = makeSuite(t, &testMetadataNested{}, false, []Option{Timeout(time.Minute)}) })
+	if result.Failed || suite.options.equateEmpty != true || suite.options.timeout != time.Minute || suite.parallel {
+		t.Fatalf("Options passed to Suite were not applied: %+v", suite.options)
+	}
+}
+
+func TestSuiteMetadataIncorrect(t *testing.T) {
+	for _, suite := range []interface{}{&testOptionsIncorrect{}, &testParallelIncorrect{}, &testTimeoutIncorrect{}} {
+		result := internal.Run(func(t internal.T) { makeSuite(t, suite, false, nil) })
+		if !result.Failed || !errors.Is(result.TestError, errMethodSignature) {
+			t.Fatalf("allowed suite with an invalid metadata method: %T", suite)
+		}
+	}
+
+	result := internal.Run(func(t internal.T) { makeSuite(t, testMetadataNested{}, false, nil) })
+	if !result.Failed || !errors.Is(result.TestError, errReceiver) {
+		t.Fatalf("allowed suite with pointer receivers to be created from struct value")
+	}
+}
+
+type testSetupT struct{ dir string }
+
+func (t *testSetupT) Setup(is Is)    { t.dir = is.T().TempDir() }
+func (t *testSetupT) Teardown(is Is) { is(is.T() != nil, "T returned nil") }
+func (t *testSetupT) TestDir(is Is)  { is(t.dir != "", "Setup did not run") }
+
+func TestSuiteSetupT(t *testing.T) {
+	var suite testSetupT
+	Suite(t, &suite)
+
+	// Setup must not fail, which would skip TestDir instead of running it.
+	if suite.dir == "" {
+		t.Fatal("is.T cannot be used in Setup")
+	}
+}
+
+type testSetupRun struct{}
+
+func (t *testSetupRun) Setup(is Is) { is.Run("setup", func(Is) {}) }
+func (t *testSetupRun) TestA(Is)    {}
+
+func TestSuiteSetupRun(t *testing.T) {
+	result := internal.Run(func(t internal.T) { makeSuite(t, &testSetupRun{}, false, nil).Run(t) })
+	runCleanup(result)
+
+	var names []string
+	for _, test := range result.RunTests {
+		names = append(names, test.Name)
+	}
+	if got := strings.Join(names, ","); result.Failed || got != "setup,TestA" {
+		t.Fatalf("Subtest was not run inside Setup: %s %s", got, result.FailMessage)
+	}
+}
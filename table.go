@@ -0,0 +1,217 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Table runs each element of cases as a subtest.
+// cases must be a slice of structs and fn must be a function that takes [Is] and the case type.
+//
+//	type testCase struct {
+//		Name     string
+//		Input    string
+//		Expected int
+//		Skip     bool `is:"skip"`
+//	}
+//
+//	is.Table(t, []testCase{{Name: "empty", Input: "", Expected: 0}}, func(is is.Is, c testCase) int {
+//		return len(c.Input)
+//	})
+//
+// The name of each subtest is the value of the string field tagged with `is:"name"` or the field named
+// Name. Cases without a name are named using their index.
+//
+// Boolean fields tagged with `is:"parallel"`, `is:"skip"` and `is:"only"` (or named Parallel, Skip and Only)
+// control how each case is run.
+// Parallel cases are run in parallel with each other. Skipped cases are reported as skipped.
+// If any case is marked with only, all other cases are skipped.
+//
+// If fn returns a value, the case struct must have a field tagged with `is:"expected"` or a field named
+// Expected. The returned value is compared with the value of the field using [Is.Equal].
+func Table(t *testing.T, cases interface{}, fn interface{}, opts ...Option) {
+	t.Helper()
+	makeTable(t, cases, fn, opts).Run(t)
+}
+
+type table struct {
+	options *options
+	cases   []*tableCase
+}
+
+type tableCase struct {
+	Name     string
+	Parallel bool
+	Skip     string
+	Func     func(Is)
+}
+
+func (tb *table) Run(t internal.T) {
+	t.Helper()
+
+	info := newTestInfo(t, nil, false, 0)
+	for _, c := range tb.cases {
+		c := c
+		runT(t, info, tb.options, c.Name, c.Parallel, tb.options.timeout, func(is Is) {
+			if c.Skip != "" {
+				is.t().Skipf("%s", c.Skip)
+				return
+			}
+			c.Func(is)
+		})
+	}
+}
+
+func makeTable(t internal.T, cases interface{}, fn interface{}, opts []Option) (tb *table) {
+	// see makeSuite
+	var calledFatal bool
+
+	defer func() {
+		if calledFatal {
+			return
+		}
+
+		if r := recover(); r != nil {
+			t.Fatalf("is.Table: Internal error: %s\n%s", r, debug.Stack())
+		}
+	}()
+
+	fatal := func(err error, f string, args ...interface{}) {
+		t.Helper()
+		calledFatal = true
+
+		if internal, ok := t.(*internal.Test); ok {
+			internal.SetError(err)
+		}
+
+		t.Fatalf(f, args...)
+	}
+
+	t.Helper()
+
+	tb = &table{options: newOptions(opts)}
+
+	casesValue := reflect.ValueOf(cases)
+	if k := casesValue.Kind(); (k != reflect.Slice && k != reflect.Array) || casesValue.Type().Elem().Kind() != reflect.Struct {
+		fatal(errTableCases, "is.Table: cases must be a slice of structs not %T", cases)
+	}
+	caseType := casesValue.Type().Elem()
+
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		fatal(errTableFunc, "is.Table: fn must be a func(Is, %s) not %T", caseType, fn)
+	}
+
+	fnType := fnValue.Type()
+	isType := reflect.TypeOf(Is(nil))
+	if fnType.NumIn() != 2 || fnType.In(0) != isType || fnType.In(1) != caseType || fnType.NumOut() > 1 {
+		fatal(errTableFunc, "is.Table: fn must be a func(Is, %s) with at most one return value not %s", caseType, fnType)
+	}
+
+	fields := tableFields(caseType)
+	if fnType.NumOut() == 1 {
+		if fields.expected == nil {
+			fatal(errTableFunc, "is.Table: fn returns a value but %s does not have an Expected field", caseType)
+		}
+
+		if !fnType.Out(0).AssignableTo(caseType.FieldByIndex(fields.expected).Type) && !caseType.FieldByIndex(fields.expected).Type.AssignableTo(fnType.Out(0)) {
+			fatal(errTableFunc, "is.Table: the return value of fn cannot be compared with the Expected field of %s", caseType)
+		}
+	}
+
+	var only bool
+	for i := 0; i < casesValue.Len(); i++ {
+		caseValue := casesValue.Index(i)
+
+		c := &tableCase{Name: fmt.Sprintf("#%02d", i)}
+		if fields.name != nil {
+			if name := caseValue.FieldByIndex(fields.name).String(); name != "" {
+				c.Name = name
+			}
+		}
+
+		if fields.parallel != nil {
+			c.Parallel = caseValue.FieldByIndex(fields.parallel).Bool()
+		}
+
+		if fields.skip != nil && caseValue.FieldByIndex(fields.skip).Bool() {
+			c.Skip = "is.Table: case is marked as skipped"
+		}
+
+		if fields.only != nil && caseValue.FieldByIndex(fields.only).Bool() {
+			only = true
+		}
+
+		name := c.Name
+		c.Func = func(is Is) {
+			is.t().Helper()
+
+			out := fnValue.Call([]reflect.Value{reflect.ValueOf(is), caseValue})
+			if len(out) == 1 {
+				is.Equal(out[0].Interface(), caseValue.FieldByIndex(fields.expected).Interface(), "is.Table: case %s returned an unexpected value", name)
+			}
+		}
+
+		tb.cases = append(tb.cases, c)
+	}
+
+	if only {
+		for i, c := range tb.cases {
+			if c.Skip == "" && !casesValue.Index(i).FieldByIndex(fields.only).Bool() {
+				c.Skip = "is.Table: skipped because other cases are marked as only"
+			}
+		}
+	}
+
+	return tb
+}
+
+// caseFields are the indexes of the fields of a table case that are used by [Table].
+// Each field is nil if the case does not have the field.
+type caseFields struct {
+	name, expected       []int
+	parallel, skip, only []int
+}
+
+// tableFields finds the fields used by [Table] in the given case type.
+// Fields are found using their `is` tag, falling back to fields with the matching name.
+func tableFields(caseType reflect.Type) (fields caseFields) {
+	for i := 0; i < caseType.NumField(); i++ {
+		field := caseType.Field(i)
+
+		switch tag := field.Tag.Get("is"); {
+		case tag == "name" && field.Type.Kind() == reflect.String:
+			fields.name = field.Index
+		case tag == "expected":
+			fields.expected = field.Index
+		case tag == "parallel" && field.Type.Kind() == reflect.Bool:
+			fields.parallel = field.Index
+		case tag == "skip" && field.Type.Kind() == reflect.Bool:
+			fields.skip = field.Index
+		case tag == "only" && field.Type.Kind() == reflect.Bool:
+			fields.only = field.Index
+		}
+	}
+
+	byName := func(index *[]int, name string, kind reflect.Kind) {
+		if *index != nil {
+			return
+		}
+
+		if field, ok := caseType.FieldByName(name); ok && (kind == reflect.Invalid || field.Type.Kind() == kind) {
+			*index = field.Index
+		}
+	}
+
+	byName(&fields.name, "Name", reflect.String)
+	byName(&fields.expected, "Expected", reflect.Invalid)
+	byName(&fields.parallel, "Parallel", reflect.Bool)
+	byName(&fields.skip, "Skip", reflect.Bool)
+	byName(&fields.only, "Only", reflect.Bool)
+
+	return fields
+}
@@ -0,0 +1,116 @@
+package is
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+type tableTestCase struct {
+	Name     string
+	Input    string
+	Expected int
+	Parallel bool
+	Skip     bool
+}
+
+type tableTaggedCase struct {
+	Label string `is:"name"`
+	Input string
+	Want  int  `is:"expected"`
+	Only  bool `is:"only"`
+}
+
+func runTable(cases, fn interface{}) *internal.Test {
+	return internal.Run(func(t internal.T) { makeTable(t, cases, fn, nil).Run(t) })
+}
+
+func TestTable(t *testing.T) {
+	cases := []tableTestCase{
+		{Name: "empty", Input: "", Expected: 0},
+		{Input: "abc", Expected: 3, Parallel: true},
+		{Name: "skipped", Input: "a", Expected: 100, Skip: true},
+	}
+
+	var called []string
+	result := runTable(cases, func(is Is, c tableTestCase) int {
+		called = append(called, c.Input)
+		return len(c.Input)
+	})
+	if result.Failed {
+		t.Fatalf("Table failed: %s", strings.Join(result.FailMessage, "\n"))
+	}
+
+	expected := []internal.TestFn{{Name: "empty"}, {Name: "#01", Parallel: true}, {Name: "skipped"}}
+	if len(result.RunTests) != len(expected) {
+		t.Fatalf("Expected %d subtests not %d", len(expected), len(result.RunTests))
+	}
+	for i, e := range expected {
+		if result.RunTests[i].Name != e.Name || result.RunTests[i].Parallel != e.Parallel {
+			t.Fatalf("Incorrect subtest %d: %s", i, result.RunTests[i].Name)
+		}
+	}
+
+	if len(called) != 2 || !result.Skipped {
+		t.Fatal("Skipped case was run")
+	}
+
+	result = runTable(cases[:2], func(is Is, c tableTestCase) int { return 1 })
+	if !result.Failed || !errors.Is(result.TestError, errNotEqual) {
+		t.Fatal("Table did not compare the return value with Expected")
+	}
+
+	result = runTable(cases[:2], func(is Is, c tableTestCase) { is(c.Input == "", "failed") })
+	if !result.Failed || !errors.Is(result.TestError, errCondition) {
+		t.Fatal("Table did not report a failing case")
+	}
+}
+
+func TestTableTags(t *testing.T) {
+	cases := []tableTaggedCase{
+		{Label: "a", Input: "a", Want: 1},
+		{Label: "b", Input: "bb", Want: 2, Only: true},
+	}
+
+	var called []string
+	result := runTable(cases, func(is Is, c tableTaggedCase) int {
+		called = append(called, c.Label)
+		return len(c.Input)
+	})
+	if result.Failed {
+		t.Fatalf("Table failed: %s", strings.Join(result.FailMessage, "\n"))
+	}
+
+	if len(called) != 1 || called[0] != "b" {
+		t.Fatalf("Only did not skip the other cases: %v", called)
+	}
+}
+
+func TestTableInvalid(t *testing.T) {
+	invalid := []struct {
+		cases, fn interface{}
+		err       error
+	}{
+		{nil, func(Is, tableTestCase) {}, errTableCases},
+		{[]int{1}, func(Is, int) {}, errTableCases},
+		{[]tableTestCase{}, nil, errTableFunc},
+		{[]tableTestCase{}, func(tableTestCase) {}, errTableFunc},
+		{[]tableTestCase{}, func(Is, tableTaggedCase) {}, errTableFunc},
+		{[]tableTestCase{}, func(Is, tableTestCase) string { return "" }, errTableFunc},
+		{[]struct{ Name string }{}, func(Is, struct{ Name string }) int { return 0 }, errTableFunc},
+	}
+
+	for i, c := range invalid {
+		result := runTable(c.cases, c.fn)
+		if !result.Failed || !errors.Is(result.TestError, c.err) {
+			t.Fatalf("makeTable allowed invalid case %d: %s", i, result.FailMessage)
+		}
+	}
+}
+
+func TestTableT(t *testing.T) {
+	Table(t, []tableTestCase{{Name: "a", Input: "a", Expected: 1}, {Name: "b", Input: "bb", Expected: 2, Parallel: true}},
+		func(is Is, c tableTestCase) int { return len(c.Input) })
+}
@@ -0,0 +1,57 @@
+package is
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithinDuration checks if value and expected are at most delta apart.
+func (is Is) WithinDuration(value, expected time.Time, delta time.Duration, format string, args ...interface{}) {
+	if diff := absDuration(value.Sub(expected)); diff > delta {
+		is.t().Helper()
+		is.fail(errNotWithinDuration, fmt.Sprintf("Difference between %s and %s is %s, allowed difference is %s", value, expected, diff, delta), format, args...)
+	}
+}
+
+// Before checks if value is before expected.
+func (is Is) Before(value, expected time.Time, format string, args ...interface{}) {
+	if !value.Before(expected) {
+		is.t().Helper()
+		is.fail(errNotBefore, fmt.Sprintf("%s is not before %s, it is %s after", value, expected, value.Sub(expected)), format, args...)
+	}
+}
+
+// After checks if value is after expected.
+func (is Is) After(value, expected time.Time, format string, args ...interface{}) {
+	if !value.After(expected) {
+		is.t().Helper()
+		is.fail(errNotAfter, fmt.Sprintf("%s is not after %s, it is %s before", value, expected, expected.Sub(value)), format, args...)
+	}
+}
+
+// timeEqual compares two time.Time values using the options set by [EquateApproxTime] and
+// [EquateTimeInstant].
+func (o *options) timeEqual(x, y time.Time) bool {
+	if o.approxTime != 0 {
+		if !o.equateTimeInstant && x.Location() != y.Location() {
+			return false
+		}
+		return absDuration(x.Sub(y)) <= o.approxTime
+	}
+
+	if !o.equateTimeInstant {
+		return x == y
+	}
+	return x.Equal(y)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		// -math.MinInt64 overflows.
+		if d == -1<<63 {
+			return 1<<63 - 1
+		}
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,66 @@
+package is
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithinDuration(t *testing.T) {
+	now := time.Now()
+	mustPass(t, func(is Is) { is.WithinDuration(now, now.Add(time.Second), time.Second, "this should pass") })
+	mustPass(t, func(is Is) { is.WithinDuration(now.Add(time.Second), now, time.Second, "this should pass") })
+	mustFail(t, errNotWithinDuration, func(is Is) { is.WithinDuration(now, now.Add(-2*time.Second), time.Second, "this should fail") })
+}
+
+func TestBeforeAfter(t *testing.T) {
+	now := time.Now()
+	mustPass(t, func(is Is) { is.Before(now, now.Add(time.Second), "this should pass") })
+	mustFail(t, errNotBefore, func(is Is) { is.Before(now, now, "this should fail") })
+
+	mustPass(t, func(is Is) { is.After(now.Add(time.Second), now, "this should pass") })
+	mustFail(t, errNotAfter, func(is Is) { is.After(now, now.Add(time.Second), "this should fail") })
+}
+
+type timeTest struct {
+	T time.Time
+	D time.Duration
+}
+
+func TestOptionEquateApproxTime(t *testing.T) {
+	now := time.Now()
+	v1 := timeTest{T: now, D: time.Second}
+	v2 := timeTest{T: now.Add(time.Millisecond), D: time.Second}
+
+	if result := testEq(t, v1, v2); !result.Failed {
+		t.Fatal("Different times were considered equal without EquateApproxTime")
+	}
+
+	if result := testEq(t, v1, v2, EquateApproxTime(time.Second)); result.Failed {
+		t.Fatalf("Times were not considered equal with EquateApproxTime: %s", result.FailMessage)
+	}
+
+	if result := testEq(t, v1, v2, EquateApproxTime(time.Microsecond)); !result.Failed {
+		t.Fatal("Times outside the margin were considered equal")
+	}
+}
+
+func TestOptionEquateTimeInstant(t *testing.T) {
+	now := time.Now()
+	utc := now.UTC()
+
+	if result := testEq(t, now, utc); result.Failed {
+		t.Fatalf("Equal instants were not considered equal: %s", result.FailMessage)
+	}
+
+	if result := testEq(t, now, utc, EquateTimeInstant(false)); !result.Failed {
+		t.Fatal("Times with different locations were considered equal without EquateTimeInstant")
+	}
+
+	if result := testEq(t, now, now.Round(0), EquateTimeInstant(false)); !result.Failed {
+		t.Fatal("Times with different monotonic readings were considered equal without EquateTimeInstant")
+	}
+
+	if result := testEq(t, now, utc.Add(time.Millisecond), EquateTimeInstant(false), EquateApproxTime(time.Second)); !result.Failed {
+		t.Fatal("Times with different locations were considered equal without EquateTimeInstant")
+	}
+}
@@ -0,0 +1,106 @@
+package is
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+// Timeout sets the maximum time each subtest started using [Is.Run] or [Is.RunP] and each test in a
+// suite may run for. A test that does not complete in time fails and the stacks of all goroutines are
+// reported along with the name of the test. Other tests continue to run.
+//
+// Go cannot stop a running goroutine, so a test that times out is left running in the background.
+// Once a test times out, checks, logs and subtests that use its [Is] or the [Is] of its subtests stop the
+// calling goroutine instead of using the test. Only cleanups of the test can still use it. This does not apply to the
+// value returned by [Is.T], which must not be used after the timeout. Tests with a timeout must also not
+// call the Parallel method of [Is.T]. Use [Is.RunP] to run a parallel test with a timeout instead.
+// A zero timeout disables this option.
+func Timeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// runTimeout calls fn with is and fails t if fn does not return within the timeout.
+// If timeout is zero, fn is called directly.
+//
+// Otherwise fn is called in a separate goroutine so that the test can be failed while fn is still running.
+// Panics and calls to runtime.Goexit in fn are propagated to the goroutine running the test.
+func runTimeout(t internal.T, timeout time.Duration, is Is, fn func(Is)) {
+	t.Helper()
+
+	if timeout <= 0 {
+		fn(is)
+		return
+	}
+
+	s := is.state()
+
+	// goroutines started by fn are started by the test, even after the goroutines running fn exit.
+	addGoroutine := func() {
+		if s.options.detectLeaks {
+			s.test.addGoroutine()
+		}
+	}
+
+	done := make(chan recoverResult, 1)
+	go func() {
+		addGoroutine()
+		done <- callRecover(func() { addGoroutine(); fn(is) })
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		switch {
+		case r.goexit:
+			// fn called t.FailNow or t.SkipNow which already marked the test as finished.
+			runtime.Goexit()
+		case r.panicked:
+			if _, ok := t.(*internal.Test); ok {
+				// internal.Test uses panics to stop the test.
+				panic(r.value)
+			}
+			panic(fmt.Sprintf("%v\n\n%s", r.value, r.stack))
+		}
+	case <-timer.C:
+		// stop fn from using t. This is set before the timeout is reported so that fn cannot fail t
+		// after the test fails. Cleanups run in this goroutine, so they can still use t.
+		s.test.timeoutMu.Lock()
+		s.test.timedOut = true
+		s.test.testGoroutine = currentGoroutine()
+		s.test.timeoutMu.Unlock()
+
+		if internal, ok := t.(*internal.Test); ok {
+			internal.SetError(errTestTimeout)
+		}
+
+		t.Errorf("is: test %s timed out after %s\n\n%s", t.Name(), timeout, stacks(true))
+		t.FailNow()
+	}
+}
+
+// checkTimeout stops the calling goroutine if the test or one of the tests that started it has timed out,
+// unless it is called by the goroutine running the test that timed out.
+// The tests cannot time out until the returned function is called, so t can be used until then.
+func (s *state) checkTimeout() (release func()) {
+	var locked []*testInfo
+	release = func() {
+		for _, test := range locked {
+			test.timeoutMu.RUnlock()
+		}
+	}
+
+	for test := s.test; test != nil; test = test.parent {
+		test.timeoutMu.RLock()
+		locked = append(locked, test)
+		if test.timedOut && currentGoroutine() != test.testGoroutine {
+			release()
+			runtime.Goexit()
+		}
+	}
+	return release
+}
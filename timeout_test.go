@@ -0,0 +1,111 @@
+package is
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yehan2002/is/v2/internal"
+)
+
+func TestTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	result := internal.Run(func(t internal.T) {
+		newIs(t, newOptions([]Option{Timeout(20 * time.Millisecond)})).Run("stuck", func(is Is) { <-block })
+	})
+
+	if !result.Failed || !errors.Is(result.TestError, errTestTimeout) {
+		t.Fatalf("Test did not time out: %s", result.FailMessage)
+	}
+
+	message := strings.Join(result.FailMessage, "\n")
+	if !strings.Contains(message, "test /stuck timed out after 20ms") || !strings.Contains(message, "goroutine ") {
+		t.Fatalf("Incorrect failure message: %s", message)
+	}
+
+	result = internal.Run(func(t internal.T) {
+		newIs(t, &options{}).RunTimeout("stuck", 20*time.Millisecond, func(is Is) { <-block })
+	})
+	if !result.Failed || !errors.Is(result.TestError, errTestTimeout) {
+		t.Fatalf("Test did not time out: %s", result.FailMessage)
+	}
+}
+
+func TestTimeoutPass(t *testing.T) {
+	mustPass(t, func(is Is) {
+		var called bool
+		is.RunTimeout("fast", time.Second, func(is Is) { called = true })
+		is(called, "test was not called")
+	})
+
+	mustFail(t, errCalledFail, func(is Is) {
+		is.RunTimeout("fail", time.Second, func(is Is) { is.Fail("failed") })
+	})
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "panic value") {
+			t.Fatalf("Panic was not propagated: %v", r)
+		}
+	}()
+
+	test := &internal.Test{}
+	runTimeout(test, time.Second, newIs(test, &options{}), func(is Is) { panic("panic value") })
+}
+
+func TestTimeoutCompleted(t *testing.T) {
+	block, done := make(chan struct{}), make(chan struct{})
+	var checked bool
+
+	result := internal.Run(func(t internal.T) {
+		newIs(t, &options{}).RunTimeout("stuck", 20*time.Millisecond, func(is Is) {
+			defer close(done)
+			<-block
+
+			is.Log("log after timeout")
+			is(false, "check after timeout")
+			checked = true
+		})
+	})
+	runCleanup(result)
+
+	messages := len(result.FailMessage)
+	close(block)
+	<-done
+
+	if checked || len(result.FailMessage) != messages {
+		t.Fatalf("Test was used after it timed out: %s", result.FailMessage)
+	}
+}
+
+func TestTimeoutBeforeCleanup(t *testing.T) {
+	block, done := make(chan struct{}), make(chan struct{})
+	var checked, cleanup bool
+
+	result := internal.Run(func(t internal.T) {
+		newIs(t, &options{}).RunTimeout("stuck", 20*time.Millisecond, func(is Is) {
+			is.t().Cleanup(func() { is.Log("cleanup after timeout"); cleanup = true })
+			defer close(done)
+			<-block
+
+			is(false, "check after timeout")
+			checked = true
+		})
+	})
+
+	// the test timed out but its cleanups have not run yet.
+	messages := len(result.FailMessage)
+	close(block)
+	<-done
+
+	if checked || len(result.FailMessage) != messages {
+		t.Fatalf("Test was used after it timed out: %s", result.FailMessage)
+	}
+
+	runCleanup(result)
+	if !cleanup {
+		t.Fatal("Cleanup could not use the test after it timed out")
+	}
+}
@@ -2,7 +2,14 @@ package is
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/yehan2002/is/v2/internal"
@@ -13,32 +20,219 @@ var (
 	errNilSuite        = errors.New("test suite is nil")
 	errMethodSignature = errors.New("invalid method signature for Setup/Teardown")
 	errReceiver        = errors.New("got value not pointer to value")
+	errSuiteTeardown   = errors.New("suite teardown failed")
+	errSuiteFactory    = errors.New("invalid suite factory")
+	errNestedSuite     = errors.New("invalid nested suite")
+	errShuffleSeed     = errors.New("invalid shuffle seed")
+	errDependency      = errors.New("invalid test dependencies")
+	errTableCases      = errors.New("table cases must be a slice of structs")
+	errTableFunc       = errors.New("invalid table function signature")
 
 	errCalledFail    = errors.New("Fail() was called")
 	errErrorNotMatch = errors.New("error did not match")
 	errFuncNoPanic   = errors.New("function did not panic")
 	errNotEqual      = errors.New("values are not equal")
 	errCondition     = errors.New("condition was not true")
+
+	errUnexpectedError      = errors.New("unexpected error")
+	errErrorNotAs           = errors.New("error is not assignable to target")
+	errErrorNotContain      = errors.New("error does not contain substring")
+	errErrorNotMatchPattern = errors.New("error does not match pattern")
+
+	errFuncPanic     = errors.New("function panicked")
+	errFuncGoexit    = errors.New("function called runtime.Goexit")
+	errPanicNotEqual = errors.New("recovered value is not equal")
+	errPanicNotMatch = errors.New("recovered value does not match pattern")
+
+	errUnsupportedType  = errors.New("value has an unsupported type")
+	errNotContains      = errors.New("value does not contain element")
+	errElementsNotMatch = errors.New("elements do not match")
+	errNotSubset        = errors.New("value is not a subset")
+	errLen              = errors.New("value has incorrect length")
+
+	errNotInDelta   = errors.New("difference exceeds delta")
+	errNotInEpsilon = errors.New("relative error exceeds epsilon")
+
+	errNotWithinDuration = errors.New("times are not within duration")
+	errNotBefore         = errors.New("time is not before")
+	errNotAfter          = errors.New("time is not after")
+
+	errInvalidJSON  = errors.New("invalid JSON")
+	errJSONNotEqual = errors.New("JSON values are not equal")
+
+	errSnapshot       = errors.New("failed to read or write snapshot")
+	errGoldenMissing  = errors.New("golden file does not exist")
+	errGoldenNotEqual = errors.New("value does not match golden file")
+
+	errSnapshotNotEqual = errors.New("value does not match inline snapshot")
+
+	errHTTPRequest = errors.New("failed to send HTTP request")
+	errHTTPStatus  = errors.New("HTTP status does not match")
+	errHTTPHeader  = errors.New("HTTP header does not match")
+	errHTTPCookie  = errors.New("HTTP cookie does not match")
+	errHTTPBody    = errors.New("HTTP body does not match")
+
+	errGoroutineLeak = errors.New("goroutine leaked")
+	errTestTimeout   = errors.New("test timed out")
+
+	errFileSystem   = errors.New("file system operation failed")
+	errTreeNotEqual = errors.New("directory tree does not match")
+
+	errParallel = errors.New("cannot modify global state in parallel tests")
+
+	errNotEventually   = errors.New("condition was not eventually true")
+	errNotConsistently = errors.New("condition was not consistently true")
+
+	errPropertyFunc   = errors.New("invalid property function")
+	errPropertySeed   = errors.New("invalid property seed")
+	errPropertyFailed = errors.New("property does not hold")
 )
 
 // runT runs the given test function using [*testing.T].
 // If the package is being tested, [internal.Test] is used instead.
-func runT(t internal.T, opts *options, name string, parallel bool, fn func(Is)) {
+// parent is the test running the subtest. parent may be nil if the parent test does not have an [Is].
+// If timeout is not zero, the test fails if fn does not return within the timeout. See [runTimeout].
+// runT returns the subtest. Unless the subtest is parallel, it has finished running when runT returns.
+// If t records failures, the subtest is run using the test t records failures for. See [unwrapT].
+func runT(t internal.T, parent *testInfo, opts *options, name string, parallel bool, timeout time.Duration, fn func(Is)) (subtest internal.T) {
+	t = unwrapT(t)
 	if testingT, ok := t.(*testing.T); ok {
 		testingT.Run(name, func(t *testing.T) {
+			subtest = t
 			t.Helper()
 			if parallel {
 				t.Parallel()
 			}
 
-			fn(newIs(t, opts))
+			runTimeout(t, timeout, newTest(t, parent, opts, parallel, timeout), fn)
 		})
 	} else if internalT, ok := t.(*internal.Test); ok {
-		internalT.Run(name, parallel, func(t *internal.Test) { fn(newIs(t, opts)) })
+		internalT.Run(name, parallel, func(t *internal.Test) {
+			subtest = t
+			runTimeout(t, timeout, newTest(t, parent, opts, parallel, timeout), fn)
+		})
+	} else {
+		t.Helper()
+		t.Fatalf("is: cannot run subtest %s using %T", name, t)
 	}
 
+	return subtest
 }
 
 func cmpValue(v1, v2 interface{}, options *options) string {
 	return cmp.Diff(v1, v2, options.CmpOpts()...)
 }
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(state{}).PkgPath()
+
+// callSite returns the location of the first caller outside this package.
+// Test files of this package are treated as callers outside the package.
+func callSite() string {
+	if frame, ok := callerFrame(); ok {
+		return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+	}
+	return "unknown"
+}
+
+// callerFrame returns the stack frame of the first caller outside this package.
+// See [callSite].
+func callerFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPath+".") || strings.HasSuffix(frame.File, "_test.go") {
+			return frame, true
+		}
+
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// errChain formats err and every error wrapped by it.
+// Each wrapped error is indented one level deeper than the error that wraps it.
+func errChain(err error) string {
+	var b strings.Builder
+
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		indent := strings.Repeat("\t", depth+1)
+		fmt.Fprintf(&b, "\n%s%T: %s", indent, err, strings.ReplaceAll(err.Error(), "\n", "\n"+indent))
+
+		switch err := err.(type) {
+		case interface{ Unwrap() error }:
+			if wrapped := err.Unwrap(); wrapped != nil {
+				walk(wrapped, depth+1)
+			}
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range err.Unwrap() {
+				if wrapped != nil {
+					walk(wrapped, depth+1)
+				}
+			}
+		}
+	}
+
+	walk(err, 0)
+	return b.String()
+}
+
+// recoverResult is the result of calling a function using callRecover.
+type recoverResult struct {
+	value    interface{}
+	panicked bool
+	goexit   bool
+	stack    []byte
+}
+
+// callRecover calls fn in a new goroutine and reports if fn panicked or called [runtime.Goexit].
+// fn is called in a separate goroutine so that a call to runtime.Goexit can be detected instead of
+// stopping the calling goroutine.
+func callRecover(fn func()) (r recoverResult) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// this is reset if fn returns or panics. runtime.Goexit skips the reset.
+		r.goexit = true
+		func() {
+			var returned bool
+			defer func() {
+				if !returned {
+					r.value = recover()
+					r.panicked = true
+					r.stack = debug.Stack()
+				}
+			}()
+
+			fn()
+			returned = true
+		}()
+		r.goexit = false
+	}()
+
+	<-done
+
+	if r.goexit {
+		r.panicked = false
+		r.stack = nil
+	}
+	return
+}
+
+// stacks returns the formatted stack trace of the calling goroutine.
+// If all is set, the stack traces of all other goroutines are included.
+func stacks(all bool) []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}